@@ -0,0 +1,40 @@
+package esnsi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrChecksumMismatch - данные документа не соответствуют объявленной контрольной сумме.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ChecksumFunc - функция вычисления контрольной суммы документа.
+// Получает исходные данные документа и возвращает контрольную сумму
+// в том же виде, в каком она указывается в атрибуте checksum заголовка simple-classifier.
+//
+// Алгоритм контрольной суммы не описан ни в схеме ЦНСИ 2.0.0.0 (doc/cnsi-2.0.0.0.xsd),
+// ни в методических рекомендациях ЕСНСИ, а в доступных выгрузках атрибут checksum равен "0".
+// Поэтому реализация по умолчанию не предоставляется: функция вычисления задается пользователем,
+// например, по алгоритму, согласованному с оператором ЕСНСИ.
+type ChecksumFunc func(data []byte) (string, error)
+
+// checksumDeclared - возвращает true, если контрольная сумма объявлена в документе.
+// Значения "" и "0" означают, что контрольная сумма не рассчитана.
+func checksumDeclared(declared string) bool {
+	return declared != "" && declared != "0"
+}
+
+// verifyChecksum - проверяет данные документа по объявленной контрольной сумме.
+func verifyChecksum(fn ChecksumFunc, declared string, data []byte) error {
+	if !checksumDeclared(declared) {
+		return nil
+	}
+	computed, err := fn(data)
+	if err != nil {
+		return fmt.Errorf("failed to compute checksum: %w", err)
+	}
+	if computed != declared {
+		return fmt.Errorf("%w: declared %s, computed %s", ErrChecksumMismatch, declared, computed)
+	}
+	return nil
+}
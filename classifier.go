@@ -2,9 +2,45 @@ package esnsi
 
 // Classifier - простой классификатор ЕСНСИ
 type Classifier[T any] struct {
-	Name    string
-	Code    string
-	UID     string
-	Version int
+	Meta
 	Records []T
 }
+
+// Meta - метаданные простого классификатора ЕСНСИ (заголовок simple-classifier).
+type Meta struct {
+	Name            string      `json:"name"`                 // Наименование. Пример: "Клиентские службы СФР"
+	Code            string      `json:"code"`                 // Код. Пример: "SFR_CO"
+	UID             string      `json:"uid"`                  // Идентификатор. Пример: "2fad55ce-854c-4044-873e-7ae806cc94cb"
+	Version         int         `json:"version"`              // Номер ревизии. Пример: 55
+	PublicID        string      `json:"public_id"`            // Публичный идентификатор на портале ЕСНСИ. Пример: "01-10991"
+	TechName        string      `json:"tech_name"`            // Техническое наименование. Пример: "OKATO_RST"
+	Description     string      `json:"description"`          // Описание
+	UpdatePeriod    int         `json:"update_period"`        // Периодичность обновления в днях (0, если не задана). Пример: 365
+	Checksum        string      `json:"checksum"`             // Контрольная сумма документа как в исходном файле. Пример: "0"
+	KeyAttributeRef string      `json:"key_attribute_ref"`    // Идентификатор ключевого атрибута
+	Attributes      []Attribute `json:"attributes,omitempty"` // Атрибуты в порядке следования в заголовке
+}
+
+// AttrType - тип атрибута классификатора.
+type AttrType string
+
+const (
+	AttrString    AttrType = "string"    // Строка (string-attribute)
+	AttrText      AttrType = "text"      // Текст (text-attribute)
+	AttrInteger   AttrType = "integer"   // Целое число (integer-attribute)
+	AttrBoolean   AttrType = "boolean"   // Логическое значение (boolean-attribute), не поддерживается декодером
+	AttrDate      AttrType = "date"      // Дата (date-attribute), не поддерживается декодером
+	AttrDecimal   AttrType = "decimal"   // Десятичное число (decimal-attribute), не поддерживается декодером
+	AttrReference AttrType = "reference" // Ссылка (reference-attribute), не поддерживается декодером
+)
+
+// Attribute - атрибут классификатора.
+type Attribute struct {
+	UID      string   `json:"uid"`       // Идентификатор. Пример: "ccbfe331-5e63-4e6e-8bb6-d4cc7446682f"
+	Name     string   `json:"name"`      // Наименование. Пример: "Код"
+	TechName string   `json:"tech_name"` // Техническое наименование. Пример: "code"
+	Type     AttrType `json:"type"`      // Тип
+	Required bool     `json:"required"`  // Обязательный
+	Unique   bool     `json:"unique"`    // Уникальный
+	Length   int      `json:"length"`    // Максимальная длина строки (0, если не задана). Пример: 255
+}
@@ -5,6 +5,38 @@ Package classifiers предоставляет готовые к использ
 
   - Sfr - Клиентские службы СФР
   - Okato - Общероссийский классификатор объектов административно-территориального деления (ОКАТО)
+  - Oktmo - Общероссийский классификатор территорий муниципальных образований (ОКТМО)
+
+# Автоматическое определение справочника
+
+Open создает справочник по коду классификатора из заголовка файла: *Sfr, *Okato, *Oktmo или справочник,
+зарегистрированный функцией Register. Для неизвестных кодов возвращается *esnsi.Classifier[esnsi.DynamicRecord].
+OpenDir загружает все справочники из XML-файлов каталога:
+
+	c, err := classifiers.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch c := c.(type) {
+	case *classifiers.Sfr:
+		fmt.Println("Клиентские службы СФР:", len(c.Records))
+	case *classifiers.Okato:
+		fmt.Println("ОКАТО:", len(c.Records))
+	}
+
+# Типы населенных пунктов
+
+ParseSettlementName выделяет тип населенного пункта или территории из наименования:
+"г Барнаул" - SettlementCity и "Барнаул", "Алейский р-н" - SettlementDistrict и "Алейский".
+Для записей ОКАТО результат сохраняется в полях OkatoRecord.Settlement и OkatoRecord.AdditionalSettlement.
+
+# Субъекты РФ
+
+Regions, RegionByOkato, RegionByCode и RegionByISO возвращают субъекты РФ из встроенной таблицы:
+код ОКАТО, код субъекта по Конституции РФ, код ISO 3166-2:RU, федеральный округ и часовой пояс.
+Sfr.Region определяет субъект по коду региона СФР (SfrRecord.RegionCode):
+
+	reg, ok := sfr.Region("013") // Республика Татарстан, ОКАТО "92", код субъекта "16"
 
 # Пример использования справочника Sfr
 
@@ -38,7 +70,7 @@ Package classifiers предоставляет готовые к использ
 		fmt.Printf("Всего записей: %d\n", len(sfr.Records))
 
 		// Поиск службы по точному коду ОКАТО
-		if service := sfr.ByOkato["77401"]; service != nil {
+		if service, ok := sfr.ByOkato.Get("77401"); ok {
 			fmt.Printf("Найдена служба: %s\n", service.ToSfrName)
 			fmt.Printf("Адрес: %s\n", service.Address)
 		}
@@ -1,12 +1,15 @@
 package classifiers
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/okato"
 )
 
 // Okato - классификатор "Общероссийский классификатор объектов
@@ -15,43 +18,64 @@ import (
 // https://esnsi.gosuslugi.ru/classifiers/16270
 type Okato struct {
 	esnsi.Classifier[OkatoRecord]
-	byCode   map[string]*OkatoRecord   // Индекс по коду ОКАТО
-	byCode11 map[string]*OkatoRecord   // Индекс по полному коду ОКАТО 11 символов
-	byRegion map[string][]*OkatoRecord // Индекс по региону (первые 2 символа кода ОКАТО)
-	Region   map[string]*OkatoRecord   // Регионы
+	byCode   *esnsi.UniqueIndex[okato.Code, OkatoRecord] // Индекс по коду ОКАТО
+	byCode11 *esnsi.UniqueIndex[string, OkatoRecord]     // Индекс по полному коду ОКАТО 11 символов
+	byRegion *esnsi.MultiIndex[string, OkatoRecord]      // Индекс по региону (первые 2 символа кода ОКАТО)
+	Region   map[string]*OkatoRecord                     // Регионы
+	tree     *codeTree[okato.Code, OkatoRecord]          // Иерархия записей
+
+	Rejected []RejectedRecord // Записи с невалидным кодом, не добавленные в классификатор
+	Repaired []RejectedRecord // Записи с невалидным кодом, восстановленным из autokey (см. WithCodeRepair)
+
+	// CheckDigitErrors - записи, контрольное число которых (поле K4) не совпадает
+	// с рассчитанным по коду ОКАТО. Такие записи остаются в классификаторе.
+	CheckDigitErrors []*okato.CheckDigitError
+}
+
+// NewOkato - создает новый классификатор Okato из XML-данных с параметрами по умолчанию
+// (см. NewOkatoWithOptions).
+func NewOkato(r io.Reader) (*Okato, error) {
+	return NewOkatoWithOptions(r)
 }
 
-// NewOkato - создает новый классификатор Okato из XML-данных.
+// NewOkatoWithOptions - создает новый классификатор Okato из XML-данных.
 //
 // Примечание: по состоянию на сентябрь 2025 в классификаторе
 // содержатся невалидные коды ОКАТО, например ";;classifierOkato_75.249.550".
-// Поэтому при разборе записи с невалидным кодом игнорируются и не добавляются.
-func NewOkato(r io.Reader) (*Okato, error) {
-	byCode := make(map[string]*OkatoRecord)
-	byCode11 := make(map[string]*OkatoRecord)
-	byRegion := make(map[string][]*OkatoRecord)
-	Region := make(map[string]*OkatoRecord)
+// По умолчанию записи с невалидным кодом не добавляются в классификатор
+// и перечисляются в поле Rejected. Параметр WithCodeRepair восстанавливает код из поля autokey,
+// параметр WithStrictCodes делает невалидный код ошибкой разбора.
+// При создании из снимка (NewOkatoFromSnapshot) поля Rejected и Repaired не заполняются.
+//
+// Контрольные числа записей проверяются, несовпадения сохраняются в поле CheckDigitErrors.
+func NewOkatoWithOptions(r io.Reader, opts ...OkatoOption) (*Okato, error) {
+	opt := &okatoOptions{}
+	for _, fn := range opts {
+		fn(opt)
+	}
 
-	var c esnsi.Classifier[OkatoRecord]
+	var (
+		c                  esnsi.Classifier[OkatoRecord]
+		rejected, repaired []RejectedRecord
+	)
 	if err := esnsi.NewDecoder[OkatoRecord](r).WithHandler(func(rec *OkatoRecord) error {
 		// Разбираем код ОКАТО
 		if err := rec.ParseCode(); err != nil {
-			// Если код невалидный, пропускаем запись
-			return nil
+			rr := RejectedRecord{C: rec.C, AutoKey: rec.AutoKey, UID: rec.UID, Name: rec.Name, Reason: err.Error()}
+			switch {
+			case opt.repair && rec.repairCode():
+				// Код восстановлен из autokey
+				repaired = append(repaired, rr)
+			case opt.strict:
+				return fmt.Errorf("record %s: %w", rec.UID, err)
+			default:
+				// Если код невалидный, пропускаем запись
+				rejected = append(rejected, rr)
+				return nil
+			}
 		}
-		// Добавляем запись в индексы по коду ОКАТО
-		if _, exists := byCode[rec.Code]; exists {
-			return fmt.Errorf("duplicate OKATO code '%s'", rec.Code)
-		}
-		byCode[rec.Code] = rec
-		byCode11[rec.Code11] = rec
-		byRegion[rec.Region] = append(byRegion[rec.Region], rec)
-
-		// Добавляем запись в список регионов, если это регион (код из 2 символов)
-		if len(rec.Code) == 2 {
-			Region[rec.Code] = rec
-		}
-
+		// Разбираем тип населенного пункта в наименовании
+		rec.ParseName()
 		// Добавляем запись в классификатор
 		c.Records = append(c.Records, *rec)
 		return nil
@@ -59,13 +83,148 @@ func NewOkato(r io.Reader) (*Okato, error) {
 		return nil, fmt.Errorf("error decoding: %w", err)
 	}
 
-	return &Okato{
-		Classifier: c,
-		byCode:     byCode,
-		byCode11:   byCode11,
-		byRegion:   byRegion,
-		Region:     Region,
-	}, nil
+	o, err := newOkato(c)
+	if err != nil {
+		return nil, err
+	}
+	o.Rejected, o.Repaired = rejected, repaired
+	return o, nil
+}
+
+// OkatoOption - параметр разбора классификатора Okato (см. NewOkatoWithOptions).
+type OkatoOption func(*okatoOptions)
+
+// okatoOptions - параметры разбора классификатора Okato.
+type okatoOptions struct {
+	strict bool // Невалидный код - ошибка разбора
+	repair bool // Восстанавливать невалидный код из autokey
+}
+
+// WithStrictCodes - NewOkatoWithOptions возвращает ошибку, если код записи невалиден
+// и не восстановлен (см. WithCodeRepair).
+func WithStrictCodes() OkatoOption {
+	return func(o *okatoOptions) {
+		o.strict = true
+	}
+}
+
+// WithCodeRepair - восстанавливает невалидный код записи из суффикса поля autokey
+// (например, "75.249.550" из "classifierOkato_75.249.550"). Восстановленные записи
+// добавляются в классификатор и перечисляются в поле Repaired. Если атрибута autokey
+// нет в справочнике, код не восстанавливается.
+func WithCodeRepair() OkatoOption {
+	return func(o *okatoOptions) {
+		o.repair = true
+	}
+}
+
+// RejectedRecord - запись классификатора ОКАТО с невалидным кодом.
+type RejectedRecord struct {
+	C       string // Код как в исходном справочнике, например ";;classifierOkato_75.249.550"
+	AutoKey string // Значение autokey, например "classifierOkato_75.249.550"
+	UID     string // Идентификатор записи
+	Name    string // Наименование
+	Reason  string // Причина, например "invalid OKATO code ';;classifierOkato_75.249.550'"
+}
+
+// NewOkatoFromSnapshot - создает новый классификатор Okato из снимка,
+// записанного методом WriteSnapshot.
+func NewOkatoFromSnapshot(r io.Reader) (*Okato, error) {
+	var c esnsi.Classifier[OkatoRecord]
+	if err := esnsi.ReadSnapshot(r, &c); err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	return newOkato(c)
+}
+
+// WriteSnapshot - записывает классификатор в w в бинарном формате снимка.
+func (o *Okato) WriteSnapshot(w io.Writer) error {
+	return esnsi.WriteSnapshot(w, &o.Classifier)
+}
+
+// newOkato - создает классификатор Okato из разобранных записей и строит индексы.
+func newOkato(c esnsi.Classifier[OkatoRecord]) (*Okato, error) {
+	o := &Okato{Classifier: c}
+
+	// Строим индексы по коду ОКАТО
+	var err error
+	if o.byCode, err = esnsi.NewUniqueIndex(&o.Classifier, func(rec *OkatoRecord) okato.Code { return okato.Code(rec.Code) }); err != nil {
+		return nil, okatoIndexError(err)
+	}
+	if o.byCode11, err = esnsi.NewUniqueIndex(&o.Classifier, func(rec *OkatoRecord) string { return rec.Code11 }); err != nil {
+		return nil, okatoIndexError(err)
+	}
+	o.byRegion = esnsi.NewMultiIndex(&o.Classifier, func(rec *OkatoRecord) []string { return []string{rec.Region} })
+
+	// Заполняем список регионов (записи с кодом из 2 символов)
+	o.Region = make(map[string]*OkatoRecord)
+	for i := range o.Records {
+		if rec := &o.Records[i]; okato.Code(rec.Code).Level() == 0 {
+			o.Region[rec.Region] = rec
+		}
+	}
+
+	// Строим иерархию записей
+	o.tree = newCodeTree(o.Records, o.byCode, func(rec *OkatoRecord) okato.Code { return okato.Code(rec.Code) }, okatoParentCodes)
+
+	// Проверяем контрольные числа
+	for i := range o.Records {
+		rec := &o.Records[i]
+		if rec.K4 == "" {
+			continue
+		}
+		var ce *okato.CheckDigitError
+		if err = okato.Verify(rec.C, rec.K4); errors.As(err, &ce) {
+			o.CheckDigitErrors = append(o.CheckDigitErrors, ce)
+		}
+	}
+
+	return o, nil
+}
+
+// Lookup - возвращает запись по коду ОКАТО в любом формате, допустимом для okato.Parse:
+// с точками ("01.201.800"), без точек ("01201800") или полном 11-символьном формате ("01201800000").
+func (o *Okato) Lookup(code string) (*OkatoRecord, bool) {
+	c, err := okato.Parse(code)
+	if err != nil {
+		return nil, false
+	}
+	return o.LookupCode(c)
+}
+
+// LookupCode - возвращает запись по коду ОКАТО.
+func (o *Okato) LookupCode(code okato.Code) (*OkatoRecord, bool) {
+	return o.byCode.Get(code)
+}
+
+// Lookup11 - возвращает запись по полному 11-символьному коду ОКАТО, например "01201800000".
+func (o *Okato) Lookup11(code11 string) (*OkatoRecord, bool) {
+	return o.byCode11.Get(code11)
+}
+
+// RegionRecords - возвращает все записи региона (первые 2 символа кода ОКАТО, например "01")
+// в порядке записей классификатора.
+func (o *Okato) RegionRecords(region string) []*OkatoRecord {
+	return o.byRegion.Get(region)
+}
+
+// Regions - возвращает записи регионов, отсортированные по коду ОКАТО.
+func (o *Okato) Regions() []*OkatoRecord {
+	regions := make([]*OkatoRecord, 0, len(o.Region))
+	for _, rec := range o.Region {
+		regions = append(regions, rec)
+	}
+	slices.SortFunc(regions, func(a, b *OkatoRecord) int { return okato.Code(a.Code).Compare(okato.Code(b.Code)) })
+	return regions
+}
+
+// okatoIndexError - преобразует ошибку построения индекса по коду ОКАТО.
+func okatoIndexError(err error) error {
+	var dup *esnsi.DuplicateKeyError
+	if errors.As(err, &dup) {
+		return fmt.Errorf("duplicate OKATO code '%s'", dup.Key)
+	}
+	return err
 }
 
 // reValidOkatoC - регулярное выражение для проверки корректности кода ОКАТО
@@ -76,51 +235,87 @@ var reValidOkatoC = regexp.MustCompile(`^\d{2}(\.\d{3}(\.\d{3}(\.\d{3})?)?)?$`)
 type OkatoRecord struct {
 	// Данные из файла справочника
 	C              string `esnsi:"Код"`                   // Код, например "01.201.800"
-	K4             string `esnsi:"КЧ"`                    // Неизвестно (контрольное число?). Пример: "1"
+	K4             string `esnsi:"КЧ"`                    // Контрольное число (см. okato.CheckDigit). Пример: "1"
 	Name           string `esnsi:"Наименование"`          // Наименование территориального объекта. Пример: "Сельсоветы Алейского р-на"
 	AdditionalData string `esnsi:"Дополнительные данные"` // Дополнительные сведения. Как правило, название центрального населенного пункта. Пример: "с Толстая Дуброва"
+	AutoKey        string `esnsi:"autokey,optional"`      // Уникальный ключ записи, если атрибут есть в справочнике. Пример: "classifierOkato_01.201.800"
+	UID            string `esnsi:",uid"`                  // Идентификатор записи
 
 	// Данные, полученные при разборе
-	Code   string // Код ОКАТО, например "0120188"
-	Code11 string // Код ОКАТО, полный 11-символьный, например "01201800000"
-	Region string // Регион (первые два символа кода ОКАТО), например "01"
-	Level1 string // Уровень 1: район/город (символы с 3 по 5 кода ОКАТО), например "201"
-	Level2 string // Уровень 2: рабочий поселок/сельсовет (символы с 6 по 8 кода ОКАТО), например "800"
-	Level3 string // Уровень 3: населенный пункт (символы с 9 по 11 кода ОКАТО), например "001"
+	Code   string    // Код ОКАТО в сокращенной форме okato.Code, например "01201800"
+	Code11 string    // Код ОКАТО, полный 11-символьный, например "01201800000"
+	Region string    // Регион (первые два символа кода ОКАТО), например "01"
+	Level1 string    // Уровень 1: район/город (символы с 3 по 5 кода ОКАТО), например "201"
+	Level2 string    // Уровень 2: рабочий поселок/сельсовет (символы с 6 по 8 кода ОКАТО), например "800"
+	Level3 string    // Уровень 3: населенный пункт (символы с 9 по 11 кода ОКАТО), например "001"
+	Kind   OkatoKind // Вид объекта, например OkatoRuralCouncil
+
+	Settlement           SettlementName // Наименование, разобранное на тип и собственное наименование, например {SettlementCity, "Барнаул"}
+	AdditionalSettlement SettlementName // Дополнительные сведения, разобранные на тип и наименование, например {SettlementSelo, "Толстая Дуброва"}
 }
 
 // ParseCode - разбирает и валидирует поле C (код ОКАТО с точками),
-// заполняет поля Code, Code11, Region, Level1, Level2, Level3.
+// заполняет поля Code, Code11, Region, Level1, Level2, Level3, Kind.
 func (r *OkatoRecord) ParseCode() error {
 	// Проверяем корректность кода ОКАТО
 	if !reValidOkatoC.MatchString(r.C) {
 		return fmt.Errorf("invalid OKATO code '%s'", r.C)
 	}
 
-	// Убираем точки из кода
-	r.Code = ""
-	for _, ch := range r.C {
-		if ch != '.' {
-			r.Code += string(ch)
-		}
+	// Разбираем код без точек
+	code, err := okato.Parse(r.C)
+	if err != nil {
+		return err
 	}
+	r.Code = code.String()
 
 	// Заполняем поле Code11 (полный 11-символьный код)
-	r.Code11 = r.Code + strings.Repeat("0", 11-len(r.Code))
+	r.Code11 = code.Full11()
 
 	// Заполняем поля Region, Level1, Level2, Level3
-	if len(r.Code) >= 2 {
-		r.Region = r.Code[:2]
-	}
-	if len(r.Code) >= 5 {
-		r.Level1 = r.Code[2:5]
+	s := code.String()
+	r.Region, r.Level1, r.Level2, r.Level3 = s[:2], "", "", ""
+	if len(s) >= 5 {
+		r.Level1 = s[2:5]
 	}
-	if len(r.Code) >= 8 {
-		r.Level2 = r.Code[5:8]
+	if len(s) >= 8 {
+		r.Level2 = s[5:8]
 	}
-	if len(r.Code) == 11 {
-		r.Level3 = r.Code[8:11]
+	if len(s) == 11 {
+		r.Level3 = s[8:11]
 	}
 
+	// Определяем вид объекта
+	r.Kind = okatoKind(s)
+
 	return nil
 }
+
+// ParseName - выделяет тип населенного пункта или территории из полей Name и AdditionalData
+// (см. ParseSettlementName), заполняет поля Settlement и AdditionalSettlement.
+func (r *OkatoRecord) ParseName() {
+	r.Settlement = ParseSettlementName(r.Name)
+	r.AdditionalSettlement = ParseSettlementName(r.AdditionalData)
+}
+
+// repairCode - восстанавливает поле C из суффикса поля AutoKey после последнего символа "_"
+// и разбирает код. Возвращает false, если код не удалось восстановить.
+func (r *OkatoRecord) repairCode() bool {
+	i := strings.LastIndex(r.AutoKey, "_")
+	if i < 0 {
+		return false
+	}
+	fixed := *r
+	fixed.C = r.AutoKey[i+1:]
+	if fixed.ParseCode() != nil {
+		return false
+	}
+	*r = fixed
+	return true
+}
+
+// IsGroup - проверяет, что запись является группирующей (заголовком раздела),
+// например "01.200 Районы Алтайского края" или "01.201.800 Сельсоветы Алейского р-на".
+func (r *OkatoRecord) IsGroup() bool {
+	return okatoIsGroup(r.Code)
+}
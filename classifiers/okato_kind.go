@@ -0,0 +1,105 @@
+package classifiers
+
+import "fmt"
+
+// OkatoKind - вид объекта ОКАТО, определяемый по структуре кода.
+//
+// Виды объектов по правилам кодирования ОКАТО:
+//   - символы 1-2 - субъект РФ;
+//   - символ 3 - вид объекта второго уровня: 1 - автономный округ, 2 - район, 4 - город, 5 - поселок городского типа;
+//   - символ 6 - вид объекта третьего уровня: 3 - внутригородской район, 5 - город районного подчинения
+//     (коды 501-549) или поселок городского типа (коды 551-599), 8 - сельсовет;
+//   - символы 9-11 - населенный пункт.
+//
+// Коды, оканчивающиеся на "00", а также код третьего уровня "550" обозначают группирующие записи,
+// например "01.200 Районы Алтайского края" (см. OkatoRecord.IsGroup). Вид группирующей записи
+// совпадает с видом объектов ее раздела. Исключение - коды автономных округов второго уровня,
+// например "71.100 Ханты-Мансийский автономный округ - Югра", "11.100 Ненецкий автономный округ".
+type OkatoKind int
+
+const (
+	OkatoUnknown         OkatoKind = iota // Вид не определен
+	OkatoRegion                           // Субъект РФ: "01"
+	OkatoAutonomousOkrug                  // Автономный округ: "71.100"
+	OkatoDistrict                         // Район: "01.201"
+	OkatoCity                             // Город: "01.401"
+	OkatoUrbanSettlement                  // Поселок городского типа: "01.501", "01.201.551"
+	OkatoCityDistrict                     // Внутригородской район: "01.401.365"
+	OkatoTown                             // Город районного подчинения: "01.201.501"
+	OkatoRuralCouncil                     // Сельсовет: "01.201.802"
+	OkatoSettlement                       // Населенный пункт: "01.201.802.002"
+)
+
+// String - возвращает название вида объекта.
+func (k OkatoKind) String() string {
+	switch k {
+	case OkatoUnknown:
+		return "не определен"
+	case OkatoRegion:
+		return "субъект РФ"
+	case OkatoAutonomousOkrug:
+		return "автономный округ"
+	case OkatoDistrict:
+		return "район"
+	case OkatoCity:
+		return "город"
+	case OkatoUrbanSettlement:
+		return "поселок городского типа"
+	case OkatoCityDistrict:
+		return "внутригородской район"
+	case OkatoTown:
+		return "город районного подчинения"
+	case OkatoRuralCouncil:
+		return "сельсовет"
+	case OkatoSettlement:
+		return "населенный пункт"
+	default:
+		return fmt.Sprintf("OkatoKind(%d)", int(k))
+	}
+}
+
+// okatoKind - определяет вид объекта по коду ОКАТО без точек.
+func okatoKind(code string) OkatoKind {
+	switch len(code) {
+	case 2:
+		return OkatoRegion
+	case 5:
+		switch code[2] {
+		case '1':
+			return OkatoAutonomousOkrug
+		case '2':
+			return OkatoDistrict
+		case '4':
+			return OkatoCity
+		case '5':
+			return OkatoUrbanSettlement
+		}
+	case 8:
+		switch code[5] {
+		case '3':
+			return OkatoCityDistrict
+		case '5':
+			if code[5:8] >= "550" {
+				return OkatoUrbanSettlement
+			}
+			return OkatoTown
+		case '8':
+			return OkatoRuralCouncil
+		}
+	case 11:
+		return OkatoSettlement
+	}
+	return OkatoUnknown
+}
+
+// okatoIsGroup - проверяет, что код ОКАТО без точек обозначает группирующую запись.
+func okatoIsGroup(code string) bool {
+	switch len(code) {
+	case 5:
+		// Автономный округ с кодом "x00" не является группирующей записью
+		return code[3:5] == "00" && code[2] != '1'
+	case 8:
+		return code[6:8] == "00" || code[5:8] == "550"
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package classifiers
+
+import "testing"
+
+func TestOkatoRecord_Kind(t *testing.T) {
+	tests := []struct {
+		c     string
+		kind  OkatoKind
+		group bool
+	}{
+		{"01", OkatoRegion, false},
+		{"71.100", OkatoAutonomousOkrug, false},
+		{"11.100", OkatoAutonomousOkrug, false},
+		{"71.140", OkatoAutonomousOkrug, false},
+		{"01.200", OkatoDistrict, true},
+		{"01.201", OkatoDistrict, false},
+		{"01.400", OkatoCity, true},
+		{"01.401", OkatoCity, false},
+		{"01.500", OkatoUrbanSettlement, true},
+		{"01.501", OkatoUrbanSettlement, false},
+		{"01.300", OkatoUnknown, true},
+		{"01.401.300", OkatoCityDistrict, true},
+		{"01.401.365", OkatoCityDistrict, false},
+		{"01.201.500", OkatoTown, true},
+		{"01.201.501", OkatoTown, false},
+		{"01.201.550", OkatoUrbanSettlement, true},
+		{"01.201.551", OkatoUrbanSettlement, false},
+		{"01.201.800", OkatoRuralCouncil, true},
+		{"01.201.802", OkatoRuralCouncil, false},
+		{"01.201.900", OkatoUnknown, true},
+		{"01.201.802.002", OkatoSettlement, false},
+	}
+	for _, tt := range tests {
+		rec := OkatoRecord{C: tt.c}
+		if err := rec.ParseCode(); err != nil {
+			t.Fatalf("ParseCode(%q): %v", tt.c, err)
+		}
+		if rec.Kind != tt.kind {
+			t.Errorf("%s: unexpected kind %q, expected %q", tt.c, rec.Kind, tt.kind)
+		}
+		if rec.IsGroup() != tt.group {
+			t.Errorf("%s: unexpected IsGroup %v, expected %v", tt.c, rec.IsGroup(), tt.group)
+		}
+	}
+}
+
+func TestOkatoKind_String(t *testing.T) {
+	if s := OkatoRuralCouncil.String(); s != "сельсовет" {
+		t.Errorf("unexpected string: %q", s)
+	}
+	if s := OkatoKind(100).String(); s != "OkatoKind(100)" {
+		t.Errorf("unexpected string: %q", s)
+	}
+}
+
+func TestOkato_Kind_filter(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	var districts, groups int
+	for rec := range okato.Descendants("01") {
+		if rec.IsGroup() {
+			groups++
+			continue
+		}
+		if rec.Kind == OkatoDistrict {
+			districts++
+		}
+	}
+	if districts != 1 || groups != 3 {
+		t.Errorf("unexpected counts: %d districts, %d groups", districts, groups)
+	}
+}
@@ -0,0 +1,120 @@
+package classifiers
+
+import (
+	"slices"
+	"strings"
+)
+
+// NameFormat - формат наименования записи ОКАТО в Okato.FullName.
+type NameFormat int
+
+const (
+	// NameLong - к наименованиям сельсоветов и внутригородских районов добавляется вид объекта,
+	// если он не указан в наименовании: "Толстодубровский" -> "Толстодубровский сельсовет".
+	NameLong NameFormat = iota
+	// NameShort - наименования как в классификаторе.
+	NameShort
+	// NameFull - как NameLong, сокращения типов заменяются полными наименованиями (см. SettlementType):
+	// "г Барнаул" -> "город Барнаул", "Алейский р-н" -> "Алейский район".
+	NameFull
+)
+
+// DefaultNameSeparator - разделитель наименований в Okato.FullName по умолчанию.
+const DefaultNameSeparator = ", "
+
+// FullNameOption - параметр формирования полного наименования (см. Okato.FullName).
+type FullNameOption func(*fullNameOptions)
+
+// fullNameOptions - параметры формирования полного наименования.
+type fullNameOptions struct {
+	format     NameFormat // Формат наименований
+	additional bool       // Добавлять дополнительные данные записи
+	sep        string     // Разделитель наименований
+}
+
+// WithNameFormat - задает формат наименований. По умолчанию используется NameLong.
+func WithNameFormat(f NameFormat) FullNameOption {
+	return func(o *fullNameOptions) {
+		o.format = f
+	}
+}
+
+// WithAdditionalData - добавляет к наименованию записи дополнительные данные (поле AdditionalData)
+// в скобках, например "Толстодубровский сельсовет (с Толстая Дуброва)".
+func WithAdditionalData() FullNameOption {
+	return func(o *fullNameOptions) {
+		o.additional = true
+	}
+}
+
+// WithNameSeparator - задает разделитель наименований. По умолчанию используется DefaultNameSeparator.
+func WithNameSeparator(sep string) FullNameOption {
+	return func(o *fullNameOptions) {
+		o.sep = sep
+	}
+}
+
+// FullName - возвращает полное наименование записи с кодом code (см. Lookup):
+// наименования родительских записей от региона до самой записи через разделитель.
+// Группирующие родительские записи (см. OkatoRecord.IsGroup) пропускаются.
+// Возвращает false, если запись не найдена.
+//
+// Пример для кода "01.201.816.001":
+//
+//	"Алтайский край, Алейский р-н, Толстодубровский сельсовет, с Толстая Дуброва"
+func (o *Okato) FullName(code string, opts ...FullNameOption) (string, bool) {
+	rec, ok := o.Lookup(code)
+	if !ok {
+		return "", false
+	}
+	opt := &fullNameOptions{format: NameLong, sep: DefaultNameSeparator}
+	for _, fn := range opts {
+		fn(opt)
+	}
+
+	var names []string
+	for _, p := range o.tree.ancestorsOf(rec) {
+		if !p.IsGroup() {
+			names = append(names, opt.name(p))
+		}
+	}
+	slices.Reverse(names)
+
+	name := opt.name(rec)
+	if opt.additional && rec.AdditionalData != "" {
+		additional := rec.AdditionalData
+		if opt.format == NameFull {
+			additional = rec.AdditionalSettlement.Full()
+		}
+		name += " (" + additional + ")"
+	}
+	return strings.Join(append(names, name), opt.sep), true
+}
+
+// okatoKindWords - вид объекта, добавляемый к наименованию в формате NameLong,
+// и слова, при наличии которых в наименовании вид объекта не добавляется.
+var okatoKindWords = map[OkatoKind]struct {
+	suffix string
+	skip   []string
+}{
+	OkatoRuralCouncil: {"сельсовет", []string{"сельсовет", "с/с", "сельск", "поссовет", "округ", "администрац", "территори"}},
+	OkatoCityDistrict: {"район", []string{"район", "р-н", "округ"}},
+}
+
+// name - возвращает наименование записи в заданном формате.
+func (o *fullNameOptions) name(rec *OkatoRecord) string {
+	if o.format == NameFull && rec.Settlement.Type != SettlementUnknown {
+		return rec.Settlement.Full()
+	}
+	kw, ok := okatoKindWords[rec.Kind]
+	if o.format == NameShort || !ok || rec.IsGroup() {
+		return rec.Name
+	}
+	lower := strings.ToLower(rec.Name)
+	for _, word := range kw.skip {
+		if strings.Contains(lower, word) {
+			return rec.Name
+		}
+	}
+	return rec.Name + " " + kw.suffix
+}
@@ -0,0 +1,124 @@
+package classifiers
+
+import "testing"
+
+func TestOkato_FullName(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	tests := []struct {
+		name string
+		code string
+		opts []FullNameOption
+		want string
+	}{
+		{
+			name: "settlement",
+			code: "01.201.816.001",
+			want: "Алтайский край, Алейский р-н, Толстодубровский сельсовет, с Толстая Дуброва",
+		},
+		{
+			name: "short format",
+			code: "01.201.816.001",
+			opts: []FullNameOption{WithNameFormat(NameShort)},
+			want: "Алтайский край, Алейский р-н, Толстодубровский, с Толстая Дуброва",
+		},
+		{
+			name: "full format",
+			code: "01.201.816.001",
+			opts: []FullNameOption{WithNameFormat(NameFull)},
+			want: "Алтайский край, Алейский район, Толстодубровский сельсовет, село Толстая Дуброва",
+		},
+		{
+			name: "full format with additional data",
+			code: "01.201.816",
+			opts: []FullNameOption{WithNameFormat(NameFull), WithAdditionalData()},
+			want: "Алтайский край, Алейский район, Толстодубровский сельсовет (село Толстая Дуброва)",
+		},
+		{
+			name: "additional data",
+			code: "01201816",
+			opts: []FullNameOption{WithAdditionalData()},
+			want: "Алтайский край, Алейский р-н, Толстодубровский сельсовет (с Толстая Дуброва)",
+		},
+		{
+			name: "empty additional data",
+			code: "01.201.816.002",
+			opts: []FullNameOption{WithAdditionalData()},
+			want: "Алтайский край, Алейский р-н, Толстодубровский сельсовет, п Мирный",
+		},
+		{
+			name: "separator",
+			code: "01.401",
+			opts: []FullNameOption{WithNameSeparator(" / ")},
+			want: "Алтайский край / г Барнаул",
+		},
+		{
+			name: "region",
+			code: "01",
+			want: "Алтайский край",
+		},
+		{
+			name: "group",
+			code: "01.201.800",
+			want: "Алтайский край, Алейский р-н, Сельсоветы Алейского р-на",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := okato.FullName(tt.code, tt.opts...)
+			if !ok {
+				t.Fatal("record not found")
+			}
+			if got != tt.want {
+				t.Errorf("unexpected full name: %q, expected %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := okato.FullName("02"); ok {
+		t.Error("unexpected full name for unknown code")
+	}
+}
+
+func TestFullNameOptions_name(t *testing.T) {
+	opt := &fullNameOptions{format: NameLong}
+	tests := []struct {
+		rec  OkatoRecord
+		want string
+	}{
+		{OkatoRecord{Name: "Толстодубровский", Kind: OkatoRuralCouncil, Code: "01201816"}, "Толстодубровский сельсовет"},
+		{OkatoRecord{Name: "Бочкаревский сельсовет", Kind: OkatoRuralCouncil, Code: "01201804"}, "Бочкаревский сельсовет"},
+		{OkatoRecord{Name: "Сельское поселение Заря", Kind: OkatoRuralCouncil, Code: "01201806"}, "Сельское поселение Заря"},
+		{OkatoRecord{Name: "Железнодорожный", Kind: OkatoCityDistrict, Code: "01401365"}, "Железнодорожный район"},
+		{OkatoRecord{Name: "Центральный административный округ", Kind: OkatoCityDistrict, Code: "45286"}, "Центральный административный округ"},
+		{OkatoRecord{Name: "Алейский р-н", Kind: OkatoDistrict, Code: "01201"}, "Алейский р-н"},
+	}
+	for _, tt := range tests {
+		if got := opt.name(&tt.rec); got != tt.want {
+			t.Errorf("name(%q) = %q, expected %q", tt.rec.Name, got, tt.want)
+		}
+	}
+}
+
+func TestOkato_FullName_okrug(t *testing.T) {
+	okato := openOkatoOkrug(t)
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"71.126.804.001", "Тюменская область, Ханты-Мансийский автономный округ - Югра, Сургутский р-н, сельское поселение Солнечный, п Солнечный"},
+		{"71.171", "Тюменская область, Ямало-Ненецкий автономный округ, г Салехард"},
+		{"11.111", "Архангельская область, Ненецкий автономный округ, г Нарьян-Мар"},
+	}
+	for _, tt := range tests {
+		got, ok := okato.FullName(tt.code)
+		if !ok {
+			t.Errorf("FullName(%q): record not found", tt.code)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FullName(%q) = %q, expected %q", tt.code, got, tt.want)
+		}
+	}
+}
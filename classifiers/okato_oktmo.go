@@ -0,0 +1,46 @@
+package classifiers
+
+// OkatoOktmo - сопоставление записей справочников ОКАТО и ОКТМО
+// по кодам ОКАТО, указанным в справочнике ОКТМО (поле OktmoRecord.Okato).
+type OkatoOktmo struct {
+	Okato *Okato // Справочник ОКАТО
+	Oktmo *Oktmo // Справочник ОКТМО
+}
+
+// NewOkatoOktmo - создает сопоставление записей справочников ОКАТО и ОКТМО.
+func NewOkatoOktmo(okato *Okato, oktmo *Oktmo) *OkatoOktmo {
+	return &OkatoOktmo{Okato: okato, Oktmo: oktmo}
+}
+
+// ToOktmo - возвращает записи ОКТМО, соответствующие коду ОКАТО code (см. Okato.Lookup).
+// Одному коду ОКАТО может соответствовать несколько кодов ОКТМО.
+func (m *OkatoOktmo) ToOktmo(code string) []*OktmoRecord {
+	return m.Oktmo.ByOkato(code)
+}
+
+// ToOkato - возвращает запись ОКАТО, соответствующую коду ОКТМО code (см. Oktmo.Lookup).
+// Возвращает false, если запись ОКТМО не найдена, код ОКАТО для нее не указан
+// или отсутствует в справочнике ОКАТО.
+func (m *OkatoOktmo) ToOkato(code string) (*OkatoRecord, bool) {
+	rec, ok := m.Oktmo.Lookup(code)
+	if !ok || rec.Okato == "" {
+		return nil, false
+	}
+	return m.Okato.LookupCode(rec.Okato)
+}
+
+// Unmapped - возвращает записи ОКТМО, которым не сопоставлен код ОКАТО из справочника ОКАТО,
+// в порядке записей классификатора. Группирующие записи не учитываются.
+func (m *OkatoOktmo) Unmapped() []*OktmoRecord {
+	var res []*OktmoRecord
+	for i := range m.Oktmo.Records {
+		rec := &m.Oktmo.Records[i]
+		if rec.IsGroup() {
+			continue
+		}
+		if _, ok := m.Okato.LookupCode(rec.Okato); rec.Okato == "" || !ok {
+			res = append(res, rec)
+		}
+	}
+	return res
+}
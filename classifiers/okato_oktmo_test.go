@@ -0,0 +1,64 @@
+package classifiers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOkatoOktmo(t *testing.T) {
+	m := NewOkatoOktmo(openOkatoTree(t), openOktmoOkato(t))
+
+	if got := oktmoCodes(m.ToOktmo("01.201.816")); !slices.Equal(got, []string{"01601416"}) {
+		t.Errorf("unexpected OKTMO records: %v", got)
+	}
+	if got := oktmoCodes(m.ToOktmo("01401000000")); !slices.Equal(got, []string{"01701000", "01701000001"}) {
+		t.Errorf("unexpected OKTMO records: %v", got)
+	}
+	if got := m.ToOktmo("03"); len(got) != 0 {
+		t.Errorf("unexpected OKTMO records: %v", oktmoCodes(got))
+	}
+
+	tests := []struct {
+		oktmo string
+		okato string
+	}{
+		{"01 601 416 101", "01.201.816.001"},
+		{"01601402", "01.201.802"},
+		{"01000000", "01"},
+		{"01701000001", "01.401"},
+	}
+	for _, tt := range tests {
+		rec, ok := m.ToOkato(tt.oktmo)
+		if !ok {
+			t.Errorf("ToOkato(%q): record not found", tt.oktmo)
+			continue
+		}
+		if rec.C != tt.okato {
+			t.Errorf("ToOkato(%q) = %q, expected %q", tt.oktmo, rec.C, tt.okato)
+		}
+	}
+	for _, code := range []string{"01600000", "02000000", "x"} {
+		if rec, ok := m.ToOkato(code); ok {
+			t.Errorf("ToOkato(%q): unexpected record %q", code, rec.C)
+		}
+	}
+
+	// Записей Ленинградской области нет в тестовом справочнике ОКАТО,
+	// у записи 01601416106 невалидный код ОКАТО
+	want := []string{"01601416106", "41000000", "41612000", "41612101", "41612101001"}
+	if got := oktmoCodes(m.Unmapped()); !slices.Equal(got, want) {
+		t.Errorf("unexpected unmapped records: %v, expected %v", got, want)
+	}
+}
+
+func TestOkatoOktmo_Unmapped(t *testing.T) {
+	// В тестовом справочнике ОКАТО есть только регион и с Малахово
+	m := NewOkatoOktmo(openOkato(t), openOktmoOkato(t))
+
+	got := oktmoCodes(m.Unmapped())
+	want := []string{"01601000", "01601402", "01601416", "01601416101", "01601416106", "01701000", "01701000001",
+		"41000000", "41612000", "41612101", "41612101001"}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected unmapped records: %v, expected %v", got, want)
+	}
+}
@@ -1,7 +1,9 @@
 package classifiers
 
 import (
+	"bytes"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -33,6 +35,21 @@ func TestNewOkato(t *testing.T) {
 		if okato.Version != 7 {
 			t.Errorf("unexpected Version: %d", okato.Version)
 		}
+		if okato.PublicID != "01-16270" {
+			t.Errorf("unexpected PublicID: %s", okato.PublicID)
+		}
+		if okato.TechName != "OKATO_RST" {
+			t.Errorf("unexpected TechName: %s", okato.TechName)
+		}
+		if okato.UpdatePeriod != 365 {
+			t.Errorf("unexpected UpdatePeriod: %d", okato.UpdatePeriod)
+		}
+		if okato.Checksum != "0" {
+			t.Errorf("unexpected Checksum: %s", okato.Checksum)
+		}
+		if okato.KeyAttributeRef != "358d8c23-055f-4df7-ad8c-76fd92f66336" {
+			t.Errorf("unexpected KeyAttributeRef: %s", okato.KeyAttributeRef)
+		}
 
 		// Проверяем записи
 		if len(okato.Records) != 4 {
@@ -169,48 +186,48 @@ func TestNewOkato(t *testing.T) {
 		}
 
 		// Проверяем индекс byCode
-		if len(okato.byCode) != 4 {
-			t.Errorf("unexpected byCode length: %d, expected 4", len(okato.byCode))
+		if okato.byCode.Len() != 4 {
+			t.Errorf("unexpected byCode length: %d, expected 4", okato.byCode.Len())
 		}
 
 		// Проверяем что все записи есть в индексе по коду
-		if _, exists := okato.byCode["01"]; !exists {
+		if _, exists := okato.byCode.Get("01"); !exists {
 			t.Error("record with code '01' not found in byCode index")
 		}
-		if _, exists := okato.byCode["01200"]; !exists {
+		if _, exists := okato.byCode.Get("01200"); !exists {
 			t.Error("record with code '01200' not found in byCode index")
 		}
-		if _, exists := okato.byCode["01201800"]; !exists {
+		if _, exists := okato.byCode.Get("01201800"); !exists {
 			t.Error("record with code '01201800' not found in byCode index")
 		}
-		if _, exists := okato.byCode["01201802002"]; !exists {
+		if _, exists := okato.byCode.Get("01201802002"); !exists {
 			t.Error("record with code '01201802002' not found in byCode index")
 		}
 
 		// Проверяем индекс byCode11
-		if len(okato.byCode11) != 4 {
-			t.Errorf("unexpected byCode11 length: %d, expected 4", len(okato.byCode11))
+		if okato.byCode11.Len() != 4 {
+			t.Errorf("unexpected byCode11 length: %d, expected 4", okato.byCode11.Len())
 		}
 
-		if _, exists := okato.byCode11["01000000000"]; !exists {
+		if _, exists := okato.byCode11.Get("01000000000"); !exists {
 			t.Error("record with code11 '01000000000' not found in byCode11 index")
 		}
-		if _, exists := okato.byCode11["01200000000"]; !exists {
+		if _, exists := okato.byCode11.Get("01200000000"); !exists {
 			t.Error("record with code11 '01200000000' not found in byCode11 index")
 		}
-		if _, exists := okato.byCode11["01201800000"]; !exists {
+		if _, exists := okato.byCode11.Get("01201800000"); !exists {
 			t.Error("record with code11 '01201800000' not found in byCode11 index")
 		}
-		if _, exists := okato.byCode11["01201802002"]; !exists {
+		if _, exists := okato.byCode11.Get("01201802002"); !exists {
 			t.Error("record with code11 '01201802002' not found in byCode11 index")
 		}
 
 		// Проверяем индекс byRegion
-		if len(okato.byRegion) != 1 {
-			t.Errorf("unexpected byRegion length: %d, expected 1", len(okato.byRegion))
+		if okato.byRegion.Len() != 1 {
+			t.Errorf("unexpected byRegion length: %d, expected 1", okato.byRegion.Len())
 		}
 
-		records01 := okato.byRegion["01"]
+		records01 := okato.byRegion.Get("01")
 		if len(records01) != 4 {
 			t.Errorf("unexpected number of records for region '01': %d, expected 4", len(records01))
 		}
@@ -284,3 +301,256 @@ func TestNewOkato(t *testing.T) {
 		}
 	})
 }
+
+//goland:noinspection GoUnhandledErrorResult
+func TestOkatoSnapshot(t *testing.T) {
+	f, err := os.Open("../testdata/okato-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	okato, err := NewOkato(f)
+	if err != nil {
+		t.Fatalf("failed to create OKATO classifier: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = okato.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	loaded, err := NewOkatoFromSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Classifier, okato.Classifier) {
+		t.Error("loaded classifier differs from the original")
+	}
+	if loaded.byCode.Len() != 4 || loaded.byCode11.Len() != 4 || loaded.byRegion.Len() != 1 {
+		t.Errorf("unexpected index lengths: %d, %d, %d",
+			loaded.byCode.Len(), loaded.byCode11.Len(), loaded.byRegion.Len())
+	}
+	if rec := loaded.Region["01"]; rec == nil || rec.Name != "Алтайский край" {
+		t.Errorf("unexpected region record: %+v", rec)
+	}
+}
+
+// openOkato - загружает тестовый классификатор ОКАТО.
+func openOkato(t *testing.T) *Okato {
+	t.Helper()
+	f, err := os.Open("../testdata/okato-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	okato, err := NewOkato(f)
+	if err != nil {
+		t.Fatalf("failed to create OKATO classifier: %v", err)
+	}
+	return okato
+}
+
+func TestNewOkato_noAutoKey(t *testing.T) {
+	// Атрибут autokey необязателен: без него код не восстанавливается, но справочник загружается
+	for _, opts := range [][]OkatoOption{nil, {WithCodeRepair()}} {
+		f, err := os.Open("../testdata/okato-noautokey_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		okato, err := NewOkatoWithOptions(f, opts...)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("failed to create OKATO classifier: %v", err)
+		}
+		if len(okato.Records) != 4 {
+			t.Errorf("unexpected number of records: %d, expected 4", len(okato.Records))
+		}
+		rec, ok := okato.Lookup("01.201.802.002")
+		if !ok || rec.Name != "с Малахово" || rec.AutoKey != "" {
+			t.Errorf("unexpected record: %+v", rec)
+		}
+	}
+}
+
+func TestOkato_Lookup(t *testing.T) {
+	okato := openOkato(t)
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"01", "Алтайский край"},
+		{"01000000000", "Алтайский край"},
+		{"01.200", "Районы Алтайского края"},
+		{"01.201.800", "Сельсоветы Алейского р-на"},
+		{"01201800", "Сельсоветы Алейского р-на"},
+		{"01201800000", "Сельсоветы Алейского р-на"},
+		{" 01.201.802.002 ", "с Малахово"},
+		{"01201802002", "с Малахово"},
+	}
+	for _, tt := range tests {
+		rec, ok := okato.Lookup(tt.code)
+		if !ok {
+			t.Errorf("Lookup(%q): record not found", tt.code)
+			continue
+		}
+		if rec.Name != tt.want {
+			t.Errorf("Lookup(%q) = %q, expected %q", tt.code, rec.Name, tt.want)
+		}
+	}
+
+	for _, code := range []string{"", "02", "01201", "0120180", "01.201.801"} {
+		if rec, ok := okato.Lookup(code); ok {
+			t.Errorf("Lookup(%q): unexpected record %+v", code, rec)
+		}
+	}
+
+	if rec, ok := okato.Lookup11("01200000000"); !ok || rec.C != "01.200" {
+		t.Errorf("Lookup11: unexpected record %+v", rec)
+	}
+	if _, ok := okato.Lookup11("01.200"); ok {
+		t.Error("Lookup11: unexpected record for dotted code")
+	}
+}
+
+func TestOkato_Regions(t *testing.T) {
+	okato := openOkato(t)
+
+	regions := okato.Regions()
+	if len(regions) != 1 || regions[0].Code != "01" {
+		t.Fatalf("unexpected regions: %+v", regions)
+	}
+
+	records := okato.RegionRecords("01")
+	if len(records) != 4 {
+		t.Fatalf("unexpected number of region records: %d, expected 4", len(records))
+	}
+	if records[0] != regions[0] {
+		t.Error("first region record is not the region itself")
+	}
+	if records := okato.RegionRecords("02"); len(records) != 0 {
+		t.Errorf("unexpected records for region '02': %d", len(records))
+	}
+}
+
+//goland:noinspection GoUnhandledErrorResult
+func TestNewOkato_CheckDigit(t *testing.T) {
+	if okato := openOkatoTree(t); len(okato.CheckDigitErrors) != 0 {
+		t.Errorf("unexpected check digit errors: %v", okato.CheckDigitErrors)
+	}
+
+	f, err := os.Open("../testdata/okato-checkdigit_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	okato, err := NewOkato(f)
+	if err != nil {
+		t.Fatalf("failed to create OKATO classifier: %v", err)
+	}
+	if len(okato.Records) != 4 {
+		t.Errorf("unexpected number of records: %d, expected 4", len(okato.Records))
+	}
+	if len(okato.CheckDigitErrors) != 2 {
+		t.Fatalf("unexpected number of check digit errors: %d, expected 2", len(okato.CheckDigitErrors))
+	}
+	if e := okato.CheckDigitErrors[0]; e.Code != "01.200" || e.Check != "5" || e.Want != 8 {
+		t.Errorf("unexpected check digit error: %+v", e)
+	}
+	if e := okato.CheckDigitErrors[1]; e.Code != "01.401" || e.Check != "х" {
+		t.Errorf("unexpected check digit error: %+v", e)
+	}
+}
+
+//goland:noinspection GoUnhandledErrorResult
+func TestNewOkatoWithOptions(t *testing.T) {
+	open := func(t *testing.T, opts ...OkatoOption) (*Okato, error) {
+		t.Helper()
+		f, err := os.Open("../testdata/okato-invalid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+		return NewOkatoWithOptions(f, opts...)
+	}
+	want := RejectedRecord{
+		C:       "THIS IS INVALID",
+		AutoKey: "classifierOkato_01.201.802.002",
+		UID:     "dc9f5a88-1826-481b-9174-ed6d8efa04c8",
+		Name:    "с Малахово",
+		Reason:  "invalid OKATO code 'THIS IS INVALID'",
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		okato, err := open(t)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(okato.Records) != 1 || len(okato.Repaired) != 0 {
+			t.Errorf("unexpected number of records: %d, repaired: %d", len(okato.Records), len(okato.Repaired))
+		}
+		if len(okato.Rejected) != 1 || okato.Rejected[0] != want {
+			t.Errorf("unexpected rejected records: %+v", okato.Rejected)
+		}
+		if rec := okato.Records[0]; rec.AutoKey != "classifierOkato_01.201.800" || rec.UID != "6135173a-40a7-48f6-8633-99ab89150ba3" {
+			t.Errorf("unexpected record: %+v", rec)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		_, err := open(t, WithStrictCodes())
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), want.UID) || !strings.Contains(err.Error(), want.Reason) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		okato, err := open(t, WithCodeRepair(), WithStrictCodes())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(okato.Records) != 2 || len(okato.Rejected) != 0 {
+			t.Errorf("unexpected number of records: %d, rejected: %d", len(okato.Records), len(okato.Rejected))
+		}
+		if len(okato.Repaired) != 1 || okato.Repaired[0] != want {
+			t.Errorf("unexpected repaired records: %+v", okato.Repaired)
+		}
+		rec, ok := okato.Lookup("01.201.802.002")
+		if !ok || rec.C != "01.201.802.002" || rec.Name != "с Малахово" || rec.Level3 != "002" {
+			t.Errorf("unexpected repaired record: %+v", rec)
+		}
+	})
+}
+
+func TestOkatoRecord_repairCode(t *testing.T) {
+	tests := []struct {
+		autoKey string
+		ok      bool
+		want    string
+	}{
+		{"classifierOkato_75.249.550", true, "75.249.550"},
+		{"classifierOkato_01", true, "01"},
+		{"classifierOkato_75.249.55", false, ""},
+		{"75.249.550", false, ""},
+		{"", false, ""},
+	}
+	for _, tt := range tests {
+		rec := OkatoRecord{C: ";;classifierOkato_75.249.550", AutoKey: tt.autoKey}
+		if ok := rec.repairCode(); ok != tt.ok {
+			t.Errorf("repairCode(%q) = %v, expected %v", tt.autoKey, ok, tt.ok)
+			continue
+		}
+		if tt.ok && (rec.C != tt.want || rec.Code != strings.ReplaceAll(tt.want, ".", "")) {
+			t.Errorf("repairCode(%q): unexpected record %+v", tt.autoKey, rec)
+		}
+		if !tt.ok && (rec.C != ";;classifierOkato_75.249.550" || rec.Code != "") {
+			t.Errorf("repairCode(%q): record changed: %+v", tt.autoKey, rec)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package classifiers
+
+import (
+	"iter"
+
+	"github.com/ofstudio/go-esnsi/okato"
+)
+
+// okatoParentCodes - возвращает коды возможных родителей записи с кодом code
+// в порядке от ближайшего к региону.
+//
+// Между уровнями иерархии могут находиться группирующие записи (см. OkatoRecord.IsGroup),
+// например "01.200 Районы Алтайского края" между регионом "01" и районом "01.201"
+// или "01.201.800 Сельсоветы Алейского р-на" между районом "01.201" и сельсоветом "01.201.802".
+// Такие записи являются родителями записей своего раздела.
+//
+// Записи раздела автономного округа (см. okrugOf) вложены в автономный округ, а сам округ -
+// непосредственно в регион: "71.171" вложен в "71.140", а "71.140" - в "71", но не в "71.100".
+func okatoParentCodes(code string) []string {
+	var codes []string
+	add := func(c string) {
+		if c != code {
+			codes = append(codes, c)
+		}
+	}
+	if len(code) == 11 {
+		add(code[:8])
+	}
+	if len(code) >= 8 {
+		if code[5:8] > "550" && code[5:8] < "600" {
+			add(code[:5] + "550")
+		}
+		add(code[:6] + "00")
+		add(code[:5])
+	}
+	if len(code) >= 5 {
+		if reg, ok := loadRegions().okrugOf(okato.Code(code)); ok {
+			add(string(reg.Okato))
+		} else {
+			add(code[:3] + "00")
+		}
+		add(code[:2])
+	}
+	return codes
+}
+
+// Parent - возвращает родительскую запись для записи с кодом code (см. Lookup).
+// Для регионов и неизвестных кодов возвращает false.
+func (o *Okato) Parent(code string) (*OkatoRecord, bool) {
+	rec, ok := o.Lookup(code)
+	if !ok {
+		return nil, false
+	}
+	return o.tree.parentOf(rec)
+}
+
+// Children - возвращает дочерние записи для записи с кодом code (см. Lookup)
+// в порядке записей классификатора.
+func (o *Okato) Children(code string) []*OkatoRecord {
+	rec, ok := o.Lookup(code)
+	if !ok {
+		return nil
+	}
+	return o.tree.childrenOf(rec)
+}
+
+// Ancestors - возвращает родительские записи для записи с кодом code (см. Lookup)
+// от ближайшей до региона, включая группирующие записи.
+func (o *Okato) Ancestors(code string) []*OkatoRecord {
+	rec, ok := o.Lookup(code)
+	if !ok {
+		return nil
+	}
+	return o.tree.ancestorsOf(rec)
+}
+
+// Descendants - возвращает итератор по всем вложенным записям для записи с кодом code (см. Lookup)
+// в порядке обхода в глубину: каждая запись предшествует своим дочерним записям.
+//
+// Пример - все населенные пункты района:
+//
+//	for rec := range okato.Descendants("01.201") {
+//		if rec.Level3 != "" {
+//			fmt.Println(rec.Name)
+//		}
+//	}
+func (o *Okato) Descendants(code string) iter.Seq[*OkatoRecord] {
+	rec, ok := o.Lookup(code)
+	if !ok {
+		return func(func(*OkatoRecord) bool) {}
+	}
+	return o.tree.descendantsOf(rec)
+}
@@ -0,0 +1,227 @@
+package classifiers
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+// openOkatoTree - загружает тестовый классификатор ОКАТО с полной иерархией записей.
+func openOkatoTree(t *testing.T) *Okato {
+	t.Helper()
+	f, err := os.Open("../testdata/okato-tree_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	okato, err := NewOkato(f)
+	if err != nil {
+		t.Fatalf("failed to create OKATO classifier: %v", err)
+	}
+	return okato
+}
+
+// okatoCodes - возвращает коды записей с точками.
+func okatoCodes(recs []*OkatoRecord) []string {
+	codes := make([]string, len(recs))
+	for i, rec := range recs {
+		codes[i] = rec.C
+	}
+	return codes
+}
+
+func TestOkato_Parent(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"01.200", "01"},
+		{"01.201", "01.200"},
+		{"01.201.800", "01.201"},
+		{"01.201.802", "01.201.800"},
+		{"01.201.816.001", "01.201.816"},
+		{"01201816002", "01.201.816"},
+		{"01.401", "01.400"},
+	}
+	for _, tt := range tests {
+		p, ok := okato.Parent(tt.code)
+		if !ok {
+			t.Errorf("Parent(%q): parent not found", tt.code)
+			continue
+		}
+		if p.C != tt.want {
+			t.Errorf("Parent(%q) = %q, expected %q", tt.code, p.C, tt.want)
+		}
+	}
+
+	for _, code := range []string{"01", "03", "02", "01.201.899"} {
+		if p, ok := okato.Parent(code); ok {
+			t.Errorf("Parent(%q): unexpected parent %q", code, p.C)
+		}
+	}
+}
+
+// openOkatoOkrug - загружает тестовый классификатор ОКАТО с автономными округами.
+func openOkatoOkrug(t *testing.T) *Okato {
+	t.Helper()
+	f, err := os.Open("../testdata/okato-okrug_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	okato, err := NewOkato(f)
+	if err != nil {
+		t.Fatalf("failed to create OKATO classifier: %v", err)
+	}
+	return okato
+}
+
+func TestOkato_Parent_okrug(t *testing.T) {
+	okato := openOkatoOkrug(t)
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"71.100", "71"},
+		{"71.140", "71"},
+		{"71.136", "71.100"},
+		{"71.171", "71.140"},
+		{"71.401", "71.400"},
+		{"11.100", "11"},
+		{"11.111", "11.100"},
+	}
+	for _, tt := range tests {
+		p, ok := okato.Parent(tt.code)
+		if !ok {
+			t.Errorf("Parent(%q): parent not found", tt.code)
+			continue
+		}
+		if p.C != tt.want {
+			t.Errorf("Parent(%q) = %q, expected %q", tt.code, p.C, tt.want)
+		}
+	}
+
+	got := okatoCodes(okato.Ancestors("71.126.804.001"))
+	want := []string{"71.126.804", "71.126.800", "71.126", "71.100", "71"}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected ancestors: %v, expected %v", got, want)
+	}
+	if got := okatoCodes(okato.Children("71")); !slices.Equal(got, []string{"71.100", "71.140", "71.400"}) {
+		t.Errorf("unexpected children: %v", got)
+	}
+}
+
+func TestOkato_Parent_missing(t *testing.T) {
+	// В тестовых данных нет записей "01.201" и "01.201.802": родителем становится ближайшая существующая запись
+	okato := openOkato(t)
+
+	if p, ok := okato.Parent("01.201.802.002"); !ok || p.C != "01.201.800" {
+		t.Errorf("unexpected parent of '01.201.802.002': %+v", p)
+	}
+	if p, ok := okato.Parent("01.201.800"); !ok || p.C != "01.200" {
+		t.Errorf("unexpected parent of '01.201.800': %+v", p)
+	}
+}
+
+func TestOkato_Children(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"01", []string{"01.200", "01.400"}},
+		{"01.200", []string{"01.201"}},
+		{"01.201", []string{"01.201.800"}},
+		{"01.201.800", []string{"01.201.802", "01.201.816"}},
+		{"01.201.816", []string{"01.201.816.001", "01.201.816.002"}},
+		{"01.201.816.001", nil},
+		{"03", nil},
+		{"02", nil},
+	}
+	for _, tt := range tests {
+		if got := okatoCodes(okato.Children(tt.code)); !slices.Equal(got, tt.want) {
+			t.Errorf("Children(%q) = %v, expected %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestOkato_Ancestors(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	got := okatoCodes(okato.Ancestors("01.201.816.001"))
+	want := []string{"01.201.816", "01.201.800", "01.201", "01.200", "01"}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected ancestors: %v, expected %v", got, want)
+	}
+
+	if got := okato.Ancestors("01"); len(got) != 0 {
+		t.Errorf("unexpected ancestors of region: %v", okatoCodes(got))
+	}
+	if got := okato.Ancestors("02"); got != nil {
+		t.Errorf("unexpected ancestors of unknown code: %v", okatoCodes(got))
+	}
+}
+
+func TestOkato_Descendants(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	got := okatoCodes(slices.Collect(okato.Descendants("01.201")))
+	want := []string{
+		"01.201.800",
+		"01.201.802", "01.201.802.002",
+		"01.201.816", "01.201.816.001", "01.201.816.002",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected descendants: %v, expected %v", got, want)
+	}
+
+	if n := len(slices.Collect(okato.Descendants("01"))); n != 10 {
+		t.Errorf("unexpected number of region descendants: %d, expected 10", n)
+	}
+	if n := len(slices.Collect(okato.Descendants("02"))); n != 0 {
+		t.Errorf("unexpected descendants of unknown code: %d", n)
+	}
+
+	// Прерывание обхода
+	var first []string
+	for rec := range okato.Descendants("01") {
+		first = append(first, rec.C)
+		if len(first) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(first, []string{"01.200", "01.201", "01.201.800"}) {
+		t.Errorf("unexpected first descendants: %v", first)
+	}
+}
+
+func TestOkatoParentCodes(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"01", nil},
+		{"01200", []string{"01"}},
+		{"01201", []string{"01200", "01"}},
+		{"01201800", []string{"01201", "01200", "01"}},
+		{"01201802", []string{"01201800", "01201", "01200", "01"}},
+		{"01201551", []string{"01201550", "01201500", "01201", "01200", "01"}},
+		{"01201802002", []string{"01201802", "01201800", "01201", "01200", "01"}},
+		{"71100", []string{"71"}},
+		{"71140", []string{"71"}},
+		{"71171", []string{"71140", "71"}},
+		{"71126804001", []string{"71126804", "71126800", "71126", "71100", "71"}},
+		{"11111", []string{"11100", "11"}},
+	}
+	for _, tt := range tests {
+		if got := okatoParentCodes(tt.code); !slices.Equal(got, tt.want) {
+			t.Errorf("okatoParentCodes(%q) = %v, expected %v", tt.code, got, tt.want)
+		}
+	}
+}
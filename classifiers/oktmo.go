@@ -0,0 +1,238 @@
+package classifiers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/okato"
+)
+
+// Oktmo - классификатор "Общероссийский классификатор территорий муниципальных образований (ОКТМО)".
+//
+// Код ОКТМО состоит из кода региона (2 символа) и трех уровней по 3 символа.
+// Код муниципального образования состоит из 8 символов: символ 3 - вид муниципального образования
+// первого уровня (6 - муниципальный район, 7 - городской округ и т.д.), символ 6 - вид поселения
+// (1 - городское поселение, 4 - сельское поселение, 7 - межселенная территория).
+// Код населенного пункта состоит из 11 символов. Правила иерархии ОКТМО отличаются
+// от правил ОКАТО (см. oktmoParentCodes).
+type Oktmo struct {
+	esnsi.Classifier[OktmoRecord]
+	byCode   *esnsi.UniqueIndex[string, OktmoRecord]    // Индекс по полному 11-символьному коду ОКТМО
+	byOkato  *esnsi.MultiIndex[okato.Code, OktmoRecord] // Индекс по соответствующему коду ОКАТО
+	tree     *codeTree[string, OktmoRecord]             // Иерархия записей
+	Region   map[string]*OktmoRecord                    // Регионы
+	Rejected []RejectedRecord                           // Записи с невалидным кодом, не добавленные в классификатор
+
+	// InvalidOkato - записи с невалидным соответствующим кодом ОКАТО (поле OKATO).
+	// Такие записи остаются в классификаторе с пустым полем Okato.
+	InvalidOkato []RejectedRecord
+}
+
+// NewOktmo - создает новый классификатор Oktmo из XML-данных.
+//
+// Записи с невалидным кодом ОКТМО не добавляются в классификатор и перечисляются в поле Rejected.
+// Соответствующий код ОКАТО (необязательный атрибут "ОКАТО") не влияет на добавление записи:
+// записи с невалидным кодом ОКАТО добавляются без соответствия и перечисляются в поле InvalidOkato.
+// При создании из снимка (NewOktmoFromSnapshot) поля Rejected и InvalidOkato не заполняются.
+func NewOktmo(r io.Reader) (*Oktmo, error) {
+	var (
+		c                      esnsi.Classifier[OktmoRecord]
+		rejected, invalidOkato []RejectedRecord
+	)
+	if err := esnsi.NewDecoder[OktmoRecord](r).WithHandler(func(rec *OktmoRecord) error {
+		// Разбираем код ОКТМО
+		if err := rec.ParseCode(); err != nil {
+			// Если код невалидный, пропускаем запись
+			rejected = append(rejected, RejectedRecord{C: rec.C, AutoKey: rec.AutoKey, UID: rec.UID, Name: rec.Name, Reason: err.Error()})
+			return nil
+		}
+		// Разбираем соответствующий код ОКАТО
+		if err := rec.ParseOkato(); err != nil {
+			invalidOkato = append(invalidOkato, RejectedRecord{C: rec.C, AutoKey: rec.AutoKey, UID: rec.UID, Name: rec.Name, Reason: err.Error()})
+		}
+		// Добавляем запись в классификатор
+		c.Records = append(c.Records, *rec)
+		return nil
+	}).Decode(&c); err != nil {
+		return nil, fmt.Errorf("error decoding: %w", err)
+	}
+
+	t, err := newOktmo(c)
+	if err != nil {
+		return nil, err
+	}
+	t.Rejected, t.InvalidOkato = rejected, invalidOkato
+	return t, nil
+}
+
+// NewOktmoFromSnapshot - создает новый классификатор Oktmo из снимка,
+// записанного методом WriteSnapshot.
+func NewOktmoFromSnapshot(r io.Reader) (*Oktmo, error) {
+	var c esnsi.Classifier[OktmoRecord]
+	if err := esnsi.ReadSnapshot(r, &c); err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	return newOktmo(c)
+}
+
+// WriteSnapshot - записывает классификатор в w в бинарном формате снимка.
+func (t *Oktmo) WriteSnapshot(w io.Writer) error {
+	return esnsi.WriteSnapshot(w, &t.Classifier)
+}
+
+// newOktmo - создает классификатор Oktmo из разобранных записей и строит индексы.
+func newOktmo(c esnsi.Classifier[OktmoRecord]) (*Oktmo, error) {
+	t := &Oktmo{Classifier: c}
+
+	// Строим индексы по коду ОКТМО и коду ОКАТО
+	var err error
+	if t.byCode, err = esnsi.NewUniqueIndex(&t.Classifier, oktmoCode11); err != nil {
+		var dup *esnsi.DuplicateKeyError
+		if errors.As(err, &dup) {
+			return nil, fmt.Errorf("duplicate OKTMO code '%s'", dup.Key)
+		}
+		return nil, err
+	}
+	t.byOkato = esnsi.NewMultiIndex(&t.Classifier, func(rec *OktmoRecord) []okato.Code {
+		if rec.Okato == "" {
+			return nil
+		}
+		return []okato.Code{rec.Okato}
+	})
+
+	// Заполняем список регионов
+	t.Region = make(map[string]*OktmoRecord)
+	for i := range t.Records {
+		if rec := &t.Records[i]; rec.Level1 == "" {
+			t.Region[rec.Region] = rec
+		}
+	}
+
+	// Строим иерархию записей
+	t.tree = newCodeTree(t.Records, t.byCode, oktmoCode11, oktmoParentCodes)
+
+	return t, nil
+}
+
+// oktmoCode11 - возвращает полный 11-символьный код ОКТМО записи.
+func oktmoCode11(rec *OktmoRecord) string {
+	return rec.Code11
+}
+
+// reOktmoLookup - код ОКТМО без разделителей: регион и от 0 до 3 уровней по 3 символа.
+var reOktmoLookup = regexp.MustCompile(`^\d{2}(\d{3}){0,3}$`)
+
+// Lookup - возвращает запись по коду ОКТМО с пробелами ("01 601 402"), точками ("01.601.402")
+// или без разделителей ("01601402", "01601402000"). Код может быть сокращен
+// до региона или муниципального образования первого уровня: "01" - "01 000 000", "01 601" - "01 601 000".
+func (t *Oktmo) Lookup(code string) (*OktmoRecord, bool) {
+	code = strings.NewReplacer(" ", "", ".", "").Replace(strings.TrimSpace(code))
+	if !reOktmoLookup.MatchString(code) {
+		return nil, false
+	}
+	return t.byCode.Get(code + strings.Repeat("0", 11-len(code)))
+}
+
+// Regions - возвращает записи регионов, отсортированные по коду ОКТМО.
+func (t *Oktmo) Regions() []*OktmoRecord {
+	regions := make([]*OktmoRecord, 0, len(t.Region))
+	for _, rec := range t.Region {
+		regions = append(regions, rec)
+	}
+	slices.SortFunc(regions, func(a, b *OktmoRecord) int { return strings.Compare(a.Code11, b.Code11) })
+	return regions
+}
+
+// ByOkato - возвращает записи, которым в справочнике ОКТМО сопоставлен код ОКАТО code
+// (см. okato.Parse), в порядке записей классификатора.
+func (t *Oktmo) ByOkato(code string) []*OktmoRecord {
+	c, err := okato.Parse(code)
+	if err != nil {
+		return nil
+	}
+	return t.byOkato.Get(c)
+}
+
+// reValidOktmoC - регулярное выражение для проверки корректности кода ОКТМО
+// с пробелами, точками или без разделителей (например, "01 601 402", "01601402" или "01601402106").
+var reValidOktmoC = regexp.MustCompile(`^\d{2}[ .]?\d{3}[ .]?\d{3}([ .]?\d{3})?$`)
+
+// OktmoRecord - запись классификатора Oktmo.
+type OktmoRecord struct {
+	// Данные из файла справочника
+	C              string `esnsi:"Код"`                   // Код, например "01 601 402"
+	K4             string `esnsi:"КЧ"`                    // Контрольное число. Пример: "5"
+	Name           string `esnsi:"Наименование"`          // Наименование муниципального образования или населенного пункта. Пример: "Большепанюшевский сельсовет"
+	AdditionalData string `esnsi:"Дополнительные данные"` // Дополнительные сведения, как правило, административный центр. Пример: "с Большепанюшево"
+	OKATO          string `esnsi:"ОКАТО,optional"`        // Соответствующий код ОКАТО, если атрибут есть в справочнике. Пример: "01201802000"
+	AutoKey        string `esnsi:"autokey"`               // Уникальный ключ записи. Пример: "classifierOktmo_01 601 402"
+	UID            string `esnsi:",uid"`                  // Идентификатор записи
+
+	// Данные, полученные при разборе
+	Code   string     // Код ОКТМО, 8 или 11 символов, например "01601402"
+	Code11 string     // Код ОКТМО, полный 11-символьный, например "01601402000"
+	Region string     // Регион (первые два символа кода ОКТМО), например "01"
+	Level1 string     // Уровень 1: муниципальный район/городской округ (символы с 3 по 5 кода ОКТМО), например "601"
+	Level2 string     // Уровень 2: городское/сельское поселение (символы с 6 по 8 кода ОКТМО), например "402"
+	Level3 string     // Уровень 3: населенный пункт (символы с 9 по 11 кода ОКТМО), например "106"
+	Okato  okato.Code // Соответствующий код ОКАТО, например "01201802"
+}
+
+// ParseCode - разбирает и валидирует поле C (код ОКТМО),
+// заполняет поля Code, Code11, Region, Level1, Level2, Level3.
+func (r *OktmoRecord) ParseCode() error {
+	// Проверяем корректность кода ОКТМО
+	if !reValidOktmoC.MatchString(r.C) {
+		return fmt.Errorf("invalid OKTMO code '%s'", r.C)
+	}
+
+	// Убираем разделители из кода
+	r.Code = strings.NewReplacer(" ", "", ".", "").Replace(r.C)
+	r.Code11 = r.Code + strings.Repeat("0", 11-len(r.Code))
+
+	// Заполняем поля Region, Level1, Level2, Level3 (завершающие нулевые группы не заполняются)
+	key := r.Code11
+	for len(key) > 2 && strings.HasSuffix(key, "000") {
+		key = key[:len(key)-3]
+	}
+	r.Region, r.Level1, r.Level2, r.Level3 = key[:2], "", "", ""
+	if len(key) >= 5 {
+		r.Level1 = key[2:5]
+	}
+	if len(key) >= 8 {
+		r.Level2 = key[5:8]
+	}
+	if len(key) == 11 {
+		r.Level3 = key[8:11]
+	}
+
+	return nil
+}
+
+// ParseOkato - разбирает поле OKATO (соответствующий код ОКАТО, если указан) и заполняет поле Okato.
+// Если код невалидный, поле Okato остается пустым и возвращается ошибка.
+func (r *OktmoRecord) ParseOkato() error {
+	r.Okato = ""
+	s := strings.TrimSpace(r.OKATO)
+	if s == "" {
+		return nil
+	}
+	code, err := okato.Parse(s)
+	if err != nil {
+		return err
+	}
+	r.Okato = code
+	return nil
+}
+
+// IsGroup - проверяет, что запись является группирующей (заголовком раздела),
+// например "01 600 000 Муниципальные районы Алтайского края"
+// или "01 601 400 Сельские поселения Алейского муниципального района".
+func (r *OktmoRecord) IsGroup() bool {
+	return oktmoIsGroup(r.Code11)
+}
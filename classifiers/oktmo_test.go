@@ -0,0 +1,280 @@
+package classifiers
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi/okato"
+)
+
+// openOktmo - загружает тестовый классификатор ОКТМО.
+func openOktmo(t *testing.T) *Oktmo {
+	t.Helper()
+	return openOktmoFile(t, "../testdata/oktmo-valid_test.xml")
+}
+
+// openOktmoOkato - загружает тестовый классификатор ОКТМО с атрибутом "ОКАТО".
+func openOktmoOkato(t *testing.T) *Oktmo {
+	t.Helper()
+	return openOktmoFile(t, "../testdata/oktmo-okato_test.xml")
+}
+
+// openOktmoFile - загружает классификатор ОКТМО из файла name.
+func openOktmoFile(t *testing.T, name string) *Oktmo {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	oktmo, err := NewOktmo(f)
+	if err != nil {
+		t.Fatalf("failed to create OKTMO classifier: %v", err)
+	}
+	return oktmo
+}
+
+// oktmoCodes - возвращает коды записей ОКТМО.
+func oktmoCodes(recs []*OktmoRecord) []string {
+	codes := make([]string, len(recs))
+	for i, rec := range recs {
+		codes[i] = rec.Code
+	}
+	return codes
+}
+
+func TestNewOktmo(t *testing.T) {
+	oktmo := openOktmo(t)
+
+	if oktmo.Code != "classifierOktmo" {
+		t.Errorf("unexpected classifier code: %s", oktmo.Code)
+	}
+	if len(oktmo.Records) != 18 {
+		t.Errorf("unexpected number of records: %d, expected 18", len(oktmo.Records))
+	}
+	if len(oktmo.Rejected) != 1 || oktmo.Rejected[0].C != "01 ЖЖЖ" || oktmo.Rejected[0].AutoKey != "classifierOktmo_01 ЖЖЖ" {
+		t.Errorf("unexpected rejected records: %+v", oktmo.Rejected)
+	}
+
+	rec, ok := oktmo.Lookup("01 601 402 106")
+	if !ok {
+		t.Fatal("record '01 601 402 106' not found")
+	}
+	want := OktmoRecord{
+		C:       "01 601 402 106",
+		K4:      rec.K4,
+		Name:    "с Малахово",
+		AutoKey: "classifierOktmo_01 601 402 106",
+		UID:     rec.UID,
+		Code:    "01601402106",
+		Code11:  "01601402106",
+		Region:  "01",
+		Level1:  "601",
+		Level2:  "402",
+		Level3:  "106",
+	}
+	if !reflect.DeepEqual(*rec, want) {
+		t.Errorf("unexpected record:\n%+v\nexpected:\n%+v", *rec, want)
+	}
+	if rec.K4 == "" || rec.UID == "" {
+		t.Errorf("K4 or UID is empty: %+v", rec)
+	}
+
+	if rec, ok = oktmo.Lookup("01601000"); !ok || rec.Name != "Алейский муниципальный район" ||
+		rec.Code != "01601000" || rec.Level1 != "601" || rec.Level2 != "" || rec.AdditionalData != "г Алейск" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if rec, ok = oktmo.Lookup("01701000001"); !ok || rec.Name != "г Барнаул" || rec.Level2 != "000" || rec.Level3 != "001" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	for _, code := range []string{"02", "01601403", "x"} {
+		if _, ok := oktmo.Lookup(code); ok {
+			t.Errorf("Lookup(%q): unexpected record", code)
+		}
+	}
+
+	if len(oktmo.Region) != 2 || oktmo.Region["01"] == nil || oktmo.Region["01"].Code != "01000000" {
+		t.Errorf("unexpected regions: %v", oktmo.Region)
+	}
+	if regions := oktmo.Regions(); len(regions) != 2 || regions[0].Region != "01" || regions[1].Region != "41" {
+		t.Errorf("unexpected regions: %v", oktmoCodes(regions))
+	}
+}
+
+func TestNewOktmo_okato(t *testing.T) {
+	oktmo := openOktmoOkato(t)
+
+	if len(oktmo.Records) != 18 || len(oktmo.Rejected) != 1 {
+		t.Errorf("unexpected number of records: %d, rejected: %d", len(oktmo.Records), len(oktmo.Rejected))
+	}
+	if rec, ok := oktmo.Lookup("01 601 402 106"); !ok || rec.OKATO != "01201802002" || rec.Okato != "01201802002" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+
+	// Запись с невалидным кодом ОКАТО остается в классификаторе без соответствия
+	rec, ok := oktmo.Lookup("01 601 416 106")
+	if !ok {
+		t.Fatal("record '01 601 416 106' not found")
+	}
+	if rec.OKATO != "01.201.816.02" || rec.Okato != "" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if len(oktmo.InvalidOkato) != 1 || oktmo.InvalidOkato[0].C != "01 601 416 106" ||
+		oktmo.InvalidOkato[0].UID != rec.UID || !strings.Contains(oktmo.InvalidOkato[0].Reason, "01.201.816.02") {
+		t.Errorf("unexpected invalid OKATO records: %+v", oktmo.InvalidOkato)
+	}
+}
+
+func TestNewOktmo_missingAttribute(t *testing.T) {
+	// Справочник СФР не содержит атрибута "Код"
+	f, err := os.Open("../testdata/sfr-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = NewOktmo(f)
+	if err == nil || !strings.Contains(err.Error(), "attribute Код not found") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOktmo_Hierarchy(t *testing.T) {
+	oktmo := openOktmo(t)
+
+	got := oktmoCodes(oktmo.Ancestors("01 601 402 106"))
+	want := []string{"01601402", "01601400", "01601000", "01600000", "01000000"}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected ancestors: %v, expected %v", got, want)
+	}
+
+	// Городское поселение и его населенный пункт
+	got = oktmoCodes(oktmo.Ancestors("41 612 101 001"))
+	want = []string{"41612101", "41612100", "41612000", "41600000", "41000000"}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected ancestors: %v, expected %v", got, want)
+	}
+
+	if p, ok := oktmo.Parent("01701000001"); !ok || p.Code != "01701000" {
+		t.Errorf("unexpected parent: %+v", p)
+	}
+	if _, ok := oktmo.Parent("01"); ok {
+		t.Error("unexpected parent of region")
+	}
+
+	if got := oktmoCodes(oktmo.Children("01 601 400")); !slices.Equal(got, []string{"01601402", "01601416"}) {
+		t.Errorf("unexpected children: %v", got)
+	}
+
+	got = oktmoCodes(slices.Collect(oktmo.Descendants("01 601 416")))
+	if !slices.Equal(got, []string{"01601416101", "01601416106"}) {
+		t.Errorf("unexpected descendants: %v", got)
+	}
+	if n := len(slices.Collect(oktmo.Descendants("01"))); n != 11 {
+		t.Errorf("unexpected number of region descendants: %d, expected 11", n)
+	}
+
+	var groups []string
+	for i := range oktmo.Records {
+		if rec := &oktmo.Records[i]; rec.IsGroup() {
+			groups = append(groups, rec.Code)
+		}
+	}
+	if !slices.Equal(groups, []string{"01600000", "01601400", "01700000", "41600000", "41612100"}) {
+		t.Errorf("unexpected groups: %v", groups)
+	}
+}
+
+func TestOktmo_ByOkato(t *testing.T) {
+	oktmo := openOktmoOkato(t)
+
+	if got := oktmoCodes(oktmo.ByOkato("01401")); !slices.Equal(got, []string{"01701000", "01701000001"}) {
+		t.Errorf("unexpected records for OKATO '01401': %v", got)
+	}
+	if got := oktmoCodes(oktmo.ByOkato("01.201.802.002")); !slices.Equal(got, []string{"01601402106"}) {
+		t.Errorf("unexpected records for OKATO '01.201.802.002': %v", got)
+	}
+	if got := oktmo.ByOkato("01.200"); len(got) != 0 {
+		t.Errorf("unexpected records for OKATO '01.200': %v", oktmoCodes(got))
+	}
+	if got := oktmo.ByOkato("x"); got != nil {
+		t.Errorf("unexpected records for invalid OKATO: %v", oktmoCodes(got))
+	}
+
+	// В справочнике без атрибута "ОКАТО" соответствие не задано
+	if got := openOktmo(t).ByOkato("01401"); len(got) != 0 {
+		t.Errorf("unexpected records for OKATO '01401': %v", oktmoCodes(got))
+	}
+}
+
+func TestOktmoRecord_ParseCode(t *testing.T) {
+	tests := []struct {
+		c    string
+		code string
+		ok   bool
+	}{
+		{"01 601 402", "01601402", true},
+		{"01.601.402.106", "01601402106", true},
+		{"01601402106", "01601402106", true},
+		{"01 601", "", false},
+		{"0160140210", "", false},
+	}
+	for _, tt := range tests {
+		rec := OktmoRecord{C: tt.c}
+		err := rec.ParseCode()
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseCode(%q): unexpected error: %v", tt.c, err)
+			continue
+		}
+		if tt.ok && rec.Code != tt.code {
+			t.Errorf("ParseCode(%q): unexpected code %q, expected %q", tt.c, rec.Code, tt.code)
+		}
+	}
+}
+
+func TestOktmoRecord_ParseOkato(t *testing.T) {
+	tests := []struct {
+		okato string
+		want  okato.Code
+		ok    bool
+	}{
+		{"", "", true},
+		{"01.201.802.002", "01201802002", true},
+		{"01201802000", "01201802", true},
+		{"01.2", "", false},
+	}
+	for _, tt := range tests {
+		rec := OktmoRecord{OKATO: tt.okato, Okato: "01"}
+		err := rec.ParseOkato()
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseOkato(%q): unexpected error: %v", tt.okato, err)
+		}
+		if rec.Okato != tt.want {
+			t.Errorf("ParseOkato(%q): unexpected code %q, expected %q", tt.okato, rec.Okato, tt.want)
+		}
+	}
+}
+
+func TestOktmoSnapshot(t *testing.T) {
+	oktmo := openOktmo(t)
+
+	var buf bytes.Buffer
+	if err := oktmo.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	loaded, err := NewOktmoFromSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Classifier, oktmo.Classifier) {
+		t.Error("loaded classifier differs from the original")
+	}
+	if p, ok := loaded.Parent("01601402106"); !ok || p.Code != "01601402" {
+		t.Errorf("unexpected parent: %+v", p)
+	}
+}
@@ -0,0 +1,107 @@
+package classifiers
+
+import (
+	"iter"
+	"strings"
+)
+
+// oktmoParentCodes - возвращает полные 11-символьные коды возможных родителей записи
+// с полным кодом ОКТМО code в порядке от ближайшего к региону.
+//
+// Между уровнями иерархии ОКТМО находятся группирующие записи (см. OktmoRecord.IsGroup):
+//   - "01 600 000 Муниципальные районы Алтайского края" между регионом "01 000 000"
+//     и муниципальным районом "01 601 000";
+//   - "01 601 400 Сельские поселения Алейского муниципального района" между муниципальным районом
+//     "01 601 000" и сельским поселением "01 601 402". Раздел поселения определяется 6-м символом кода:
+//     1 - городские поселения, 4 - сельские поселения, 7 - межселенные территории.
+//
+// Родитель населенного пункта "01 601 402 106" - муниципальное образование "01 601 402",
+// населенного пункта городского округа "01 701 000 001" - городской округ "01 701 000".
+func oktmoParentCodes(code string) []string {
+	if len(code) != 11 {
+		return nil
+	}
+	var codes []string
+	add := func(c string) {
+		c += strings.Repeat("0", 11-len(c))
+		if c != code {
+			codes = append(codes, c)
+		}
+	}
+	if code[8:11] != "000" {
+		add(code[:8])
+	}
+	if code[5:8] != "000" {
+		if oktmoSection(code[5]) {
+			add(code[:6] + "00")
+		}
+		add(code[:5])
+	}
+	if code[2:5] != "000" {
+		add(code[:3] + "00")
+		add(code[:2])
+	}
+	return codes
+}
+
+// oktmoSection - проверяет, что 6-й символ кода ОКТМО c обозначает раздел поселений:
+// 1 - городские поселения, 4 - сельские поселения, 7 - межселенные территории.
+func oktmoSection(c byte) bool {
+	return c == '1' || c == '4' || c == '7'
+}
+
+// oktmoIsGroup - проверяет, что полный код ОКТМО code обозначает группирующую запись:
+// раздел муниципальных образований региона ("01 600 000", "01 700 000")
+// или раздел поселений муниципального района ("01 601 100", "01 601 400", "01 601 700").
+func oktmoIsGroup(code string) bool {
+	if len(code) != 11 || code[8:11] != "000" {
+		return false
+	}
+	switch {
+	case code[5:8] != "000":
+		return oktmoSection(code[5]) && code[6:8] == "00"
+	case code[2:5] != "000":
+		return code[3:5] == "00"
+	}
+	return false
+}
+
+// Parent - возвращает родительскую запись для записи с кодом code (см. Lookup).
+// Для регионов и неизвестных кодов возвращает false.
+func (t *Oktmo) Parent(code string) (*OktmoRecord, bool) {
+	rec, ok := t.Lookup(code)
+	if !ok {
+		return nil, false
+	}
+	return t.tree.parentOf(rec)
+}
+
+// Children - возвращает дочерние записи для записи с кодом code (см. Lookup)
+// в порядке записей классификатора.
+func (t *Oktmo) Children(code string) []*OktmoRecord {
+	rec, ok := t.Lookup(code)
+	if !ok {
+		return nil
+	}
+	return t.tree.childrenOf(rec)
+}
+
+// Ancestors - возвращает родительские записи для записи с кодом code (см. Lookup)
+// от ближайшей до региона, включая группирующие записи.
+func (t *Oktmo) Ancestors(code string) []*OktmoRecord {
+	rec, ok := t.Lookup(code)
+	if !ok {
+		return nil
+	}
+	return t.tree.ancestorsOf(rec)
+}
+
+// Descendants - возвращает итератор по всем вложенным записям для записи с кодом code (см. Lookup)
+// в порядке обхода в глубину.
+func (t *Oktmo) Descendants(code string) iter.Seq[*OktmoRecord] {
+	rec, ok := t.Lookup(code)
+	if !ok {
+		return func(func(*OktmoRecord) bool) {}
+	}
+	return t.tree.descendantsOf(rec)
+}
@@ -0,0 +1,60 @@
+package classifiers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOktmoParentCodes(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"01000000000", nil},
+		{"01600000000", []string{"01000000000"}},
+		{"01601000000", []string{"01600000000", "01000000000"}},
+		{"01601400000", []string{"01601000000", "01600000000", "01000000000"}},
+		{"01601402000", []string{"01601400000", "01601000000", "01600000000", "01000000000"}},
+		{"01601402106", []string{"01601402000", "01601400000", "01601000000", "01600000000", "01000000000"}},
+		{"01701000001", []string{"01701000000", "01700000000", "01000000000"}},
+		{"41612101000", []string{"41612100000", "41612000000", "41600000000", "41000000000"}},
+		{"41612101001", []string{"41612101000", "41612100000", "41612000000", "41600000000", "41000000000"}},
+		// Межселенная территория
+		{"04639701000", []string{"04639700000", "04639000000", "04600000000", "04000000000"}},
+		// 6-й символ не обозначает раздел поселений
+		{"45382000001", []string{"45382000000", "45300000000", "45000000000"}},
+		{"01601000", nil},
+	}
+	for _, tt := range tests {
+		if got := oktmoParentCodes(tt.code); !slices.Equal(got, tt.want) {
+			t.Errorf("oktmoParentCodes(%q) = %v, expected %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestOktmoIsGroup(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"01000000000", false},
+		{"01600000000", true},
+		{"01700000000", true},
+		{"01601000000", false},
+		{"01601100000", true},
+		{"01601400000", true},
+		{"01601700000", true},
+		{"01601402000", false},
+		{"01601402106", false},
+		{"45300000000", true},
+		{"45382000000", false},
+		// Разряды 7-8 "00", но 6-й символ не обозначает раздел поселений
+		{"45382300000", false},
+		{"01601400", false},
+	}
+	for _, tt := range tests {
+		if got := oktmoIsGroup(tt.code); got != tt.want {
+			t.Errorf("oktmoIsGroup(%q) = %v, expected %v", tt.code, got, tt.want)
+		}
+	}
+}
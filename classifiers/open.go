@@ -0,0 +1,156 @@
+package classifiers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// ErrUnknownFormat - данные не являются документом простого классификатора формата ЦНСИ.
+var ErrUnknownFormat = errors.New("not a CNSI simple classifier document")
+
+// Factory - функция создания классификатора из XML-данных.
+// Пример: функция-обертка над NewOkato.
+type Factory func(r io.Reader) (any, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{
+		"SFR_CO":          func(r io.Reader) (any, error) { return NewSfr(r) },
+		"classifierOkato": func(r io.Reader) (any, error) { return NewOkato(r) },
+		"classifierOktmo": func(r io.Reader) (any, error) { return NewOktmo(r) },
+	}
+)
+
+// Register - регистрирует функцию создания классификатора с кодом code
+// (атрибут code заголовка simple-classifier). Заменяет ранее зарегистрированную функцию.
+func Register(code string, f Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[code] = f
+}
+
+// Open - создает классификатор из XML-данных, выбирая функцию создания по коду классификатора.
+// Для зарегистрированных кодов возвращает соответствующий тип (например, *Sfr или *Okato),
+// для остальных - *esnsi.Classifier[esnsi.DynamicRecord].
+// Если данные не являются документом простого классификатора, возвращает ошибку ErrUnknownFormat.
+func Open(r io.Reader) (any, error) {
+	code, r, err := peekCode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	factoriesMu.RLock()
+	f, ok := factories[code]
+	factoriesMu.RUnlock()
+	if ok {
+		return f(r)
+	}
+
+	c := &esnsi.Classifier[esnsi.DynamicRecord]{}
+	if err = esnsi.NewDecoder[esnsi.DynamicRecord](r).Decode(c); err != nil {
+		return nil, fmt.Errorf("error decoding: %v", err)
+	}
+	return c, nil
+}
+
+// Loaded - классификатор, загруженный функцией OpenDir.
+type Loaded struct {
+	Path       string // Путь к файлу
+	Code       string // Код классификатора. Пример: "SFR_CO"
+	Classifier any    // Классификатор, как его возвращает Open
+}
+
+// OpenDir - загружает все классификаторы из XML-файлов каталога dir (без подкаталогов)
+// в порядке имен файлов. Файлы, не являющиеся документами простого классификатора, пропускаются
+// (см. ErrUnknownFormat); ошибка чтения или некорректный XML в любом файле прерывает загрузку.
+func OpenDir(dir string) ([]Loaded, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var loaded []Loaded
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".xml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		l, err := openFile(path)
+		if errors.Is(err, ErrUnknownFormat) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		loaded = append(loaded, l)
+	}
+	return loaded, nil
+}
+
+// openFile - загружает классификатор из файла path.
+//
+//goland:noinspection GoUnhandledErrorResult
+func openFile(path string) (Loaded, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Loaded{}, err
+	}
+	defer f.Close()
+
+	code, r, err := peekCode(f)
+	if err != nil {
+		return Loaded{}, err
+	}
+	c, err := Open(r)
+	if err != nil {
+		return Loaded{}, err
+	}
+	return Loaded{Path: path, Code: code, Classifier: c}, nil
+}
+
+// peekCode - читает код классификатора из заголовка simple-classifier,
+// который следует сразу за корневым элементом document.
+// Возвращает поток, содержащий все данные r, включая прочитанные.
+// Ошибку ErrUnknownFormat возвращает, только если данные прочитаны без ошибок, но не содержат
+// заголовка; ошибки чтения и некорректный XML (например, обрезанный файл) возвращаются как есть.
+func peekCode(r io.Reader) (string, io.Reader, error) {
+	if r == nil {
+		return "", nil, fmt.Errorf("reader is nil")
+	}
+	var buf bytes.Buffer
+	dec := xml.NewDecoder(io.TeeReader(r, &buf))
+	for elements := 0; elements < 2; {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return "", nil, ErrUnknownFormat
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read XML: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		elements++
+		if el.Name.Local == "simple-classifier" {
+			for _, attr := range el.Attr {
+				if attr.Name.Local == "code" {
+					return attr.Value, io.MultiReader(&buf, r), nil
+				}
+			}
+			return "", nil, ErrUnknownFormat
+		}
+	}
+	return "", nil, ErrUnknownFormat
+}
@@ -0,0 +1,166 @@
+package classifiers
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+//goland:noinspection GoUnhandledErrorResult
+func TestOpen(t *testing.T) {
+	open := func(t *testing.T, name string) (any, error) {
+		t.Helper()
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+		return Open(f)
+	}
+
+	t.Run("registered codes", func(t *testing.T) {
+		c, err := open(t, "../testdata/sfr-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open: %v", err)
+		}
+		if sfr, ok := c.(*Sfr); !ok || len(sfr.Records) == 0 {
+			t.Errorf("expected *Sfr, got %T", c)
+		}
+
+		c, err = open(t, "../testdata/okato-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open: %v", err)
+		}
+		if okato, ok := c.(*Okato); !ok || len(okato.Records) == 0 {
+			t.Errorf("expected *Okato, got %T", c)
+		}
+
+		c, err = open(t, "../testdata/oktmo-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open: %v", err)
+		}
+		if oktmo, ok := c.(*Oktmo); !ok || len(oktmo.Records) == 0 {
+			t.Errorf("expected *Oktmo, got %T", c)
+		}
+	})
+
+	t.Run("dynamic fallback", func(t *testing.T) {
+		c, err := open(t, "../testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open: %v", err)
+		}
+		d, ok := c.(*esnsi.Classifier[esnsi.DynamicRecord])
+		if !ok {
+			t.Fatalf("expected dynamic classifier, got %T", c)
+		}
+		if d.Code != "TestClassifier" || len(d.Records) != 4 {
+			t.Errorf("unexpected classifier: %s, %d records", d.Code, len(d.Records))
+		}
+	})
+
+	t.Run("custom factory", func(t *testing.T) {
+		Register("TestClassifier", func(r io.Reader) (any, error) {
+			c := &esnsi.Classifier[testRecord]{}
+			if err := esnsi.NewDecoder[testRecord](r).Decode(c); err != nil {
+				return nil, err
+			}
+			return c, nil
+		})
+		defer func() {
+			factoriesMu.Lock()
+			delete(factories, "TestClassifier")
+			factoriesMu.Unlock()
+		}()
+
+		c, err := open(t, "../testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open: %v", err)
+		}
+		if _, ok := c.(*esnsi.Classifier[testRecord]); !ok {
+			t.Errorf("expected registered type, got %T", c)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		for _, data := range []string{"", "not xml", "<?xml version='1.0'?><catalog><item code='1'/></catalog>"} {
+			if _, err := Open(strings.NewReader(data)); !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("%q: expected ErrUnknownFormat, got %v", data, err)
+			}
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		errRead := errors.New("read error")
+		r := io.MultiReader(strings.NewReader("<nsi:document>"), iotest.ErrReader(errRead))
+		if _, err := Open(r); !errors.Is(err, errRead) {
+			t.Errorf("expected read error, got %v", err)
+		}
+		if _, err := Open(strings.NewReader("<nsi:document><nsi:simple-classifier code=")); err == nil || errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("expected XML error, got %v", err)
+		}
+	})
+}
+
+func TestOpenDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"b-okato.xml": "../testdata/okato-valid_test.xml",
+		"a-sfr.XML":   "../testdata/sfr-valid_test.xml",
+	} {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatalf("failed to read test file: %v", err)
+		}
+		if err = os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+	_ = os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("text"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "other.xml"), []byte("<catalog/>"), 0o644)
+
+	loaded, err := OpenDir(dir)
+	if err != nil {
+		t.Fatalf("failed to open directory: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("unexpected number of classifiers: %d, expected 2", len(loaded))
+	}
+	if loaded[0].Code != "SFR_CO" || filepath.Base(loaded[0].Path) != "a-sfr.XML" {
+		t.Errorf("unexpected first classifier: %+v", loaded[0])
+	}
+	if _, ok := loaded[1].Classifier.(*Okato); !ok || loaded[1].Code != "classifierOkato" {
+		t.Errorf("unexpected second classifier: %s, %T", loaded[1].Code, loaded[1].Classifier)
+	}
+
+	t.Run("truncated file", func(t *testing.T) {
+		dir := t.TempDir()
+		data, _ := os.ReadFile("../testdata/okato-valid_test.xml")
+		// Файл обрезан внутри заголовка simple-classifier
+		i := strings.Index(string(data), "simple-classifier")
+		_ = os.WriteFile(filepath.Join(dir, "truncated.xml"), data[:i+100], 0o644)
+		_, err := OpenDir(dir)
+		if err == nil || errors.Is(err, ErrUnknownFormat) || !strings.Contains(err.Error(), "truncated.xml") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		data, _ := os.ReadFile("../testdata/okato-duplicate_test.xml")
+		_ = os.WriteFile(filepath.Join(dir, "c-invalid.xml"), data, 0o644)
+		if _, err := OpenDir(dir); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	ToSfrCode  string `esnsi:"ToSfrCode"`
+	RegionName string `esnsi:"RegionName"`
+}
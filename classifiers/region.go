@@ -0,0 +1,217 @@
+package classifiers
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ofstudio/go-esnsi/okato"
+)
+
+// FederalDistrict - федеральный округ.
+type FederalDistrict int
+
+const (
+	FederalDistrictUnknown       FederalDistrict = iota // Округ не определен
+	FederalDistrictCentral                              // Центральный федеральный округ
+	FederalDistrictNorthwestern                         // Северо-Западный федеральный округ
+	FederalDistrictSouthern                             // Южный федеральный округ
+	FederalDistrictNorthCaucasus                        // Северо-Кавказский федеральный округ
+	FederalDistrictVolga                                // Приволжский федеральный округ
+	FederalDistrictUral                                 // Уральский федеральный округ
+	FederalDistrictSiberian                             // Сибирский федеральный округ
+	FederalDistrictFarEastern                           // Дальневосточный федеральный округ
+)
+
+// federalDistrictNames - названия федеральных округов.
+var federalDistrictNames = map[FederalDistrict]string{
+	FederalDistrictCentral:       "Центральный",
+	FederalDistrictNorthwestern:  "Северо-Западный",
+	FederalDistrictSouthern:      "Южный",
+	FederalDistrictNorthCaucasus: "Северо-Кавказский",
+	FederalDistrictVolga:         "Приволжский",
+	FederalDistrictUral:          "Уральский",
+	FederalDistrictSiberian:      "Сибирский",
+	FederalDistrictFarEastern:    "Дальневосточный",
+}
+
+// String - возвращает название федерального округа, например "Приволжский".
+func (d FederalDistrict) String() string {
+	if d == FederalDistrictUnknown {
+		return "не определен"
+	}
+	if name, ok := federalDistrictNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("FederalDistrict(%d)", int(d))
+}
+
+// Region - субъект РФ из встроенной таблицы регионов (см. Regions).
+//
+// Код ОКАТО субъекта не совпадает с кодом субъекта по Конституции РФ: например,
+// Республика Татарстан имеет код ОКАТО "92" и код субъекта "16". Автономные округа,
+// входящие в состав области, имеют 5-символьный код ОКАТО: "71.100" - Ханты-Мансийский автономный округ.
+type Region struct {
+	Okato           okato.Code      // Код ОКАТО. Пример: "92"
+	Code            string          // Код субъекта РФ (по Конституции РФ, используется в автомобильных номерах и ИНН). Пример: "16"
+	ISO             string          // Код ISO 3166-2:RU. Пример: "RU-TA". Пустая строка для субъектов, отсутствующих в стандарте
+	Name            string          // Наименование. Пример: "Республика Татарстан"
+	FederalDistrict FederalDistrict // Федеральный округ. Пример: FederalDistrictVolga
+	TimeZone        string          // Часовой пояс административного центра в базе IANA. Пример: "Europe/Moscow"
+	MSK             int             // Смещение относительно московского времени в часах. Пример: 0
+}
+
+// regionsCSV - таблица субъектов РФ.
+//
+//go:embed regions.csv
+var regionsCSV string
+
+// regionTable - таблица субъектов РФ с индексами.
+type regionTable struct {
+	list    []*Region              // Субъекты в порядке кодов ОКАТО
+	okrugs  []*Region              // Автономные округа в составе областей в порядке кодов ОКАТО
+	byOkato map[okato.Code]*Region // Индекс по коду ОКАТО
+	byCode  map[string]*Region     // Индекс по коду субъекта РФ
+	byISO   map[string]*Region     // Индекс по коду ISO 3166-2:RU
+}
+
+// loadRegions - разбирает встроенную таблицу субъектов РФ при первом обращении.
+var loadRegions = sync.OnceValue(func() *regionTable {
+	t, err := parseRegions(regionsCSV)
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// parseRegions - разбирает таблицу субъектов РФ в формате CSV с заголовком.
+func parseRegions(data string) (*regionTable, error) {
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read regions table: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("empty regions table")
+	}
+
+	districts := make(map[string]FederalDistrict, len(federalDistrictNames))
+	for d, name := range federalDistrictNames {
+		districts[name] = d
+	}
+
+	t := &regionTable{
+		byOkato: make(map[okato.Code]*Region),
+		byCode:  make(map[string]*Region),
+		byISO:   make(map[string]*Region),
+	}
+	for _, row := range rows[1:] {
+		if len(row) != 7 {
+			return nil, fmt.Errorf("invalid regions table row %v", row)
+		}
+		code, err := okato.Parse(row[0])
+		if err != nil {
+			return nil, err
+		}
+		district, ok := districts[row[4]]
+		if !ok {
+			return nil, fmt.Errorf("unknown federal district '%s'", row[4])
+		}
+		msk, err := strconv.Atoi(row[6])
+		if err != nil {
+			return nil, fmt.Errorf("invalid MSK offset '%s': %w", row[6], err)
+		}
+		reg := &Region{
+			Okato:           code,
+			Code:            row[1],
+			ISO:             row[2],
+			Name:            row[3],
+			FederalDistrict: district,
+			TimeZone:        row[5],
+			MSK:             msk,
+		}
+
+		if _, ok := t.byOkato[reg.Okato]; ok {
+			return nil, fmt.Errorf("duplicate region OKATO code '%s'", reg.Okato)
+		}
+		if _, ok := t.byCode[reg.Code]; ok {
+			return nil, fmt.Errorf("duplicate region code '%s'", reg.Code)
+		}
+		t.byOkato[reg.Okato] = reg
+		t.byCode[reg.Code] = reg
+		if reg.ISO != "" {
+			if _, ok := t.byISO[reg.ISO]; ok {
+				return nil, fmt.Errorf("duplicate region ISO code '%s'", reg.ISO)
+			}
+			t.byISO[reg.ISO] = reg
+		}
+		t.list = append(t.list, reg)
+	}
+	slices.SortFunc(t.list, func(a, b *Region) int { return a.Okato.Compare(b.Okato) })
+	for _, reg := range t.list {
+		if len(reg.Okato) == 5 {
+			t.okrugs = append(t.okrugs, reg)
+		}
+	}
+
+	return t, nil
+}
+
+// Regions - возвращает субъекты РФ из встроенной таблицы в порядке кодов ОКАТО.
+// Таблица содержит субъекты, имеющие код в классификаторе ОКАТО.
+func Regions() []*Region {
+	return slices.Clone(loadRegions().list)
+}
+
+// RegionByOkato - возвращает субъект РФ, к которому относится код ОКАТО code (см. okato.Parse).
+// Код может быть любого уровня: "92", "92.430" или "92430000000".
+// Для кодов автономных округов в составе области и их вложенных записей возвращается автономный округ
+// (см. okrugOf): "71.136" - Ханты-Мансийский автономный округ, "71.171" - Ямало-Ненецкий автономный округ,
+// "71.401" - Тюменская область.
+func RegionByOkato(code string) (*Region, bool) {
+	c, err := okato.Parse(code)
+	if err != nil || len(c) < 2 {
+		return nil, false
+	}
+	t := loadRegions()
+	if reg, ok := t.okrugOf(c); ok {
+		return reg, true
+	}
+	reg, ok := t.byOkato[c[:2]]
+	return reg, ok
+}
+
+// okrugOf - возвращает автономный округ, к разделу которого относится код ОКАТО c.
+// Раздел округа - коды второго уровня с символом 3 равным "1", начиная с кода округа
+// и до кода следующего округа той же области: "71.100"-"71.139" - Ханты-Мансийский автономный округ,
+// "71.140"-"71.199" - Ямало-Ненецкий автономный округ.
+func (t *regionTable) okrugOf(c okato.Code) (*Region, bool) {
+	if len(c) < 5 || c[2] != '1' {
+		return nil, false
+	}
+	var res *Region
+	for _, reg := range t.okrugs {
+		if reg.Okato[:3] == c[:3] && reg.Okato <= c[:5] {
+			res = reg
+		}
+	}
+	return res, res != nil
+}
+
+// RegionByCode - возвращает субъект РФ по 2-символьному коду субъекта по Конституции РФ, например "16".
+//
+// Код региона клиентской службы СФР (SfrRecord.RegionCode) использует собственную нумерацию СФР
+// и не является кодом субъекта РФ; для него используется Sfr.Region.
+func RegionByCode(code string) (*Region, bool) {
+	reg, ok := loadRegions().byCode[code]
+	return reg, ok
+}
+
+// RegionByISO - возвращает субъект РФ по коду ISO 3166-2:RU, например "RU-TA". Регистр не учитывается.
+func RegionByISO(iso string) (*Region, bool) {
+	reg, ok := loadRegions().byISO[strings.ToUpper(strings.TrimSpace(iso))]
+	return reg, ok
+}
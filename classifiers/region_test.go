@@ -0,0 +1,183 @@
+package classifiers
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/ofstudio/go-esnsi/okato"
+)
+
+func TestRegions(t *testing.T) {
+	regions := Regions()
+	if len(regions) != 85 {
+		t.Fatalf("unexpected number of regions: %d, expected 85", len(regions))
+	}
+	if regions[0].Name != "Алтайский край" || regions[len(regions)-1].Name != "Еврейская автономная область" {
+		t.Errorf("unexpected order: %s ... %s", regions[0].Name, regions[len(regions)-1].Name)
+	}
+
+	districts := make(map[FederalDistrict]int)
+	for i, reg := range regions {
+		if i > 0 && regions[i-1].Okato.Compare(reg.Okato) >= 0 {
+			t.Errorf("regions are not sorted: %s, %s", regions[i-1].Okato, reg.Okato)
+		}
+		if len(reg.Code) != 2 || reg.Name == "" {
+			t.Errorf("invalid region: %+v", reg)
+		}
+		if reg.ISO != "" && !strings.HasPrefix(reg.ISO, "RU-") {
+			t.Errorf("invalid ISO code: %+v", reg)
+		}
+		if _, err := time.LoadLocation(reg.TimeZone); err != nil {
+			t.Errorf("%s: invalid time zone: %v", reg.Name, err)
+		}
+		districts[reg.FederalDistrict]++
+	}
+	want := map[FederalDistrict]int{
+		FederalDistrictCentral:       18,
+		FederalDistrictNorthwestern:  11,
+		FederalDistrictSouthern:      8,
+		FederalDistrictNorthCaucasus: 7,
+		FederalDistrictVolga:         14,
+		FederalDistrictUral:          6,
+		FederalDistrictSiberian:      10,
+		FederalDistrictFarEastern:    11,
+	}
+	for d, n := range want {
+		if districts[d] != n {
+			t.Errorf("%s: unexpected number of regions: %d, expected %d", d, districts[d], n)
+		}
+	}
+
+	// Изменение результата не влияет на таблицу
+	regions[0] = nil
+	if Regions()[0] == nil {
+		t.Error("Regions must return a copy")
+	}
+}
+
+func TestRegionByOkato(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"92", "Республика Татарстан"},
+		{"92430000000", "Республика Татарстан"},
+		{"01.201.802.002", "Алтайский край"},
+		{"71", "Тюменская область"},
+		{"71.401", "Тюменская область"},
+		{"71100000000", "Ханты-Мансийский автономный округ - Югра"},
+		{"71.140.001", "Ямало-Ненецкий автономный округ"},
+		{"11.100", "Ненецкий автономный округ"},
+		{"71136000000", "Ханты-Мансийский автономный округ - Югра"},
+		{"71.126.804.001", "Ханты-Мансийский автономный округ - Югра"},
+		{"71.140.000", "Ямало-Ненецкий автономный округ"},
+		{"71.171", "Ямало-Ненецкий автономный округ"},
+		{"71.199", "Ямало-Ненецкий автономный округ"},
+		{"11.111", "Ненецкий автономный округ"},
+		{"11.401", "Архангельская область"},
+		{"71.200", "Тюменская область"},
+		{"45277592000", "Москва"},
+	}
+	for _, tt := range tests {
+		reg, ok := RegionByOkato(tt.code)
+		if !ok {
+			t.Errorf("RegionByOkato(%q): region not found", tt.code)
+			continue
+		}
+		if reg.Name != tt.want {
+			t.Errorf("RegionByOkato(%q) = %q, expected %q", tt.code, reg.Name, tt.want)
+		}
+	}
+	for _, code := range []string{"02", "x", ""} {
+		if reg, ok := RegionByOkato(code); ok {
+			t.Errorf("RegionByOkato(%q): unexpected region %q", code, reg.Name)
+		}
+	}
+}
+
+func TestRegionByCode(t *testing.T) {
+	reg, ok := RegionByCode("16")
+	if !ok {
+		t.Fatal("region '16' not found")
+	}
+	want := Region{
+		Okato:           okato.Code("92"),
+		Code:            "16",
+		ISO:             "RU-TA",
+		Name:            "Республика Татарстан",
+		FederalDistrict: FederalDistrictVolga,
+		TimeZone:        "Europe/Moscow",
+		MSK:             0,
+	}
+	if *reg != want {
+		t.Errorf("unexpected region:\n%+v\nexpected:\n%+v", *reg, want)
+	}
+	if reg, ok = RegionByCode("39"); !ok || reg.MSK != -1 || reg.TimeZone != "Europe/Kaliningrad" {
+		t.Errorf("unexpected region: %+v", reg)
+	}
+	for _, code := range []string{"016", "013", "00", ""} {
+		if reg, ok := RegionByCode(code); ok {
+			t.Errorf("RegionByCode(%q): unexpected region %q", code, reg.Name)
+		}
+	}
+}
+
+func TestRegionByISO(t *testing.T) {
+	if reg, ok := RegionByISO("ru-mow"); !ok || reg.Okato != "45" || reg.Code != "77" {
+		t.Errorf("unexpected region: %+v", reg)
+	}
+	if reg, ok := RegionByISO("RU-KHM"); !ok || reg.Okato != "71100" || reg.FederalDistrict != FederalDistrictUral {
+		t.Errorf("unexpected region: %+v", reg)
+	}
+	if _, ok := RegionByISO(""); ok {
+		t.Error("unexpected region for empty ISO code")
+	}
+}
+
+func TestRegion_Okato(t *testing.T) {
+	// Соединение записей ОКАТО с таблицей регионов
+	o := openOkatoTree(t)
+	for _, rec := range o.Regions() {
+		reg, ok := RegionByOkato(rec.C)
+		if !ok {
+			t.Errorf("%s: region not found", rec.C)
+			continue
+		}
+		if reg.Name != rec.Name {
+			t.Errorf("%s: unexpected region %q, expected %q", rec.C, reg.Name, rec.Name)
+		}
+	}
+}
+
+func TestFederalDistrict_String(t *testing.T) {
+	for d, want := range map[FederalDistrict]string{
+		FederalDistrictUnknown:    "не определен",
+		FederalDistrictVolga:      "Приволжский",
+		FederalDistrictFarEastern: "Дальневосточный",
+		FederalDistrict(100):      "FederalDistrict(100)",
+	} {
+		if got := d.String(); got != want {
+			t.Errorf("%d: String() = %q, expected %q", int(d), got, want)
+		}
+	}
+}
+
+func TestParseRegions(t *testing.T) {
+	const header = "okato,code,iso,name,federal_district,time_zone,msk\n"
+	for _, data := range []string{
+		"",
+		header + "92,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow\n",
+		header + "x,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow,0\n",
+		header + "92,16,RU-TA,Республика Татарстан,Волжский,Europe/Moscow,0\n",
+		header + "92,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow,x\n",
+		header + "92,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow,0\n92,17,RU-TY,Республика Тыва,Сибирский,Asia/Krasnoyarsk,4\n",
+		header + "92,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow,0\n93,16,RU-TY,Республика Тыва,Сибирский,Asia/Krasnoyarsk,4\n",
+		header + "92,16,RU-TA,Республика Татарстан,Приволжский,Europe/Moscow,0\n93,17,RU-TA,Республика Тыва,Сибирский,Asia/Krasnoyarsk,4\n",
+	} {
+		if _, err := parseRegions(data); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package classifiers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SettlementType - тип населенного пункта или территории, указанный в наименовании сокращением,
+// например "г" в "г Барнаул" или "р-н" в "Алейский р-н".
+//
+// Значения упорядочены от городов к сельским населенным пунктам, что позволяет сортировать записи по типу.
+type SettlementType int
+
+const (
+	SettlementUnknown        SettlementType = iota // Тип не указан
+	SettlementCity                                 // Город: "г"
+	SettlementUrbanType                            // Поселок городского типа: "пгт"
+	SettlementWorkers                              // Рабочий поселок: "рп"
+	SettlementResort                               // Курортный поселок: "кп"
+	SettlementDacha                                // Дачный поселок: "дп"
+	SettlementPosyolok                             // Поселок: "п"
+	SettlementSelo                                 // Село: "с"
+	SettlementStanitsa                             // Станица: "ст-ца"
+	SettlementDerevnya                             // Деревня: "д"
+	SettlementKhutor                               // Хутор: "х"
+	SettlementSloboda                              // Слобода: "сл"
+	SettlementAul                                  // Аул: "аул"
+	SettlementRailwayStation                       // Железнодорожная станция: "ж/д ст"
+	SettlementStation                              // Станция: "ст"
+	SettlementDistrict                             // Район: "р-н"
+	SettlementRuralCouncil                         // Сельсовет: "с/с"
+)
+
+// settlementTypes - сокращение и полное наименование типов.
+var settlementTypes = map[SettlementType]struct{ abbr, name string }{
+	SettlementCity:           {"г", "город"},
+	SettlementUrbanType:      {"пгт", "поселок городского типа"},
+	SettlementWorkers:        {"рп", "рабочий поселок"},
+	SettlementResort:         {"кп", "курортный поселок"},
+	SettlementDacha:          {"дп", "дачный поселок"},
+	SettlementPosyolok:       {"п", "поселок"},
+	SettlementSelo:           {"с", "село"},
+	SettlementStanitsa:       {"ст-ца", "станица"},
+	SettlementDerevnya:       {"д", "деревня"},
+	SettlementKhutor:         {"х", "хутор"},
+	SettlementSloboda:        {"сл", "слобода"},
+	SettlementAul:            {"аул", "аул"},
+	SettlementRailwayStation: {"ж/д ст", "железнодорожная станция"},
+	SettlementStation:        {"ст", "станция"},
+	SettlementDistrict:       {"р-н", "район"},
+	SettlementRuralCouncil:   {"с/с", "сельсовет"},
+}
+
+// settlementSuffix - типы, которые указываются после наименования: "Алейский р-н".
+var settlementSuffix = map[SettlementType]bool{
+	SettlementDistrict:     true,
+	SettlementRuralCouncil: true,
+}
+
+// settlementAbbrs - варианты написания типов в наименованиях, от более длинных к более коротким.
+// Сокращения с точкой ("г.", "пос.") приводятся к вариантам без точки при разборе.
+var settlementAbbrs = []struct {
+	abbr string
+	t    SettlementType
+}{
+	{"поселок городского типа", SettlementUrbanType},
+	{"железнодорожная станция", SettlementRailwayStation},
+	{"рабочий поселок", SettlementWorkers},
+	{"курортный поселок", SettlementResort},
+	{"дачный поселок", SettlementDacha},
+	{"сельсовет", SettlementRuralCouncil},
+	{"ж/д ст", SettlementRailwayStation},
+	{"станица", SettlementStanitsa},
+	{"станция", SettlementStation},
+	{"деревня", SettlementDerevnya},
+	{"поселок", SettlementPosyolok},
+	{"слобода", SettlementSloboda},
+	{"ст-ца", SettlementStanitsa},
+	{"город", SettlementCity},
+	{"район", SettlementDistrict},
+	{"хутор", SettlementKhutor},
+	{"р-н", SettlementDistrict},
+	{"с/с", SettlementRuralCouncil},
+	{"пгт", SettlementUrbanType},
+	{"пос", SettlementPosyolok},
+	{"аул", SettlementAul},
+	{"село", SettlementSelo},
+	{"рп", SettlementWorkers},
+	{"кп", SettlementResort},
+	{"дп", SettlementDacha},
+	{"сл", SettlementSloboda},
+	{"ст", SettlementStation},
+	{"г", SettlementCity},
+	{"п", SettlementPosyolok},
+	{"с", SettlementSelo},
+	{"д", SettlementDerevnya},
+	{"х", SettlementKhutor},
+}
+
+// String - возвращает полное наименование типа, например "рабочий поселок".
+func (t SettlementType) String() string {
+	if t == SettlementUnknown {
+		return "не указан"
+	}
+	if st, ok := settlementTypes[t]; ok {
+		return st.name
+	}
+	return fmt.Sprintf("SettlementType(%d)", int(t))
+}
+
+// Abbr - возвращает сокращение типа, например "рп". Для SettlementUnknown возвращает пустую строку.
+func (t SettlementType) Abbr() string {
+	return settlementTypes[t].abbr
+}
+
+// SettlementName - наименование, разобранное на тип и собственное наименование (см. ParseSettlementName).
+type SettlementName struct {
+	Type SettlementType // Тип, например SettlementCity
+	Name string         // Наименование без типа, например "Барнаул"
+}
+
+// ParseSettlementName - выделяет тип из наименования.
+// Тип распознается в начале наименования ("г Барнаул", "г. Барнаул", "рабочий поселок Тальменка"),
+// а для районов и сельсоветов - в конце ("Алейский р-н", "Кировский с/с").
+// Сокращения распознаются только в нижнем регистре. Если тип не найден,
+// возвращается SettlementUnknown и исходное наименование без пробелов в начале и в конце.
+func ParseSettlementName(s string) SettlementName {
+	s = strings.TrimSpace(s)
+	for _, a := range settlementAbbrs {
+		if settlementSuffix[a.t] {
+			if name, ok := cutSettlementSuffix(s, a.abbr); ok {
+				return SettlementName{Type: a.t, Name: name}
+			}
+			continue
+		}
+		if name, ok := cutSettlementPrefix(s, a.abbr); ok {
+			return SettlementName{Type: a.t, Name: name}
+		}
+	}
+	return SettlementName{Name: s}
+}
+
+// cutSettlementPrefix - отделяет тип abbr в начале наименования: "г Барнаул", "г.Барнаул", "г. Барнаул".
+func cutSettlementPrefix(s, abbr string) (string, bool) {
+	rest, ok := strings.CutPrefix(s, abbr)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case strings.HasPrefix(rest, "."):
+		rest = rest[1:]
+	case strings.HasPrefix(rest, " "):
+	default:
+		return "", false
+	}
+	rest = strings.TrimSpace(rest)
+	return rest, rest != ""
+}
+
+// cutSettlementSuffix - отделяет тип abbr в конце наименования: "Алейский р-н".
+func cutSettlementSuffix(s, abbr string) (string, bool) {
+	rest, ok := strings.CutSuffix(strings.TrimSuffix(s, "."), abbr)
+	if !ok || !strings.HasSuffix(rest, " ") {
+		return "", false
+	}
+	rest = strings.TrimSpace(rest)
+	return rest, rest != ""
+}
+
+// String - возвращает наименование с сокращением типа: "г Барнаул", "Алейский р-н".
+func (n SettlementName) String() string {
+	return n.format(n.Type.Abbr())
+}
+
+// Full - возвращает наименование с полным наименованием типа: "город Барнаул", "Алейский район".
+func (n SettlementName) Full() string {
+	if n.Type == SettlementUnknown {
+		return n.Name
+	}
+	return n.format(n.Type.String())
+}
+
+// format - возвращает наименование с типом typ в начале или в конце в зависимости от типа.
+func (n SettlementName) format(typ string) string {
+	switch {
+	case typ == "":
+		return n.Name
+	case settlementSuffix[n.Type]:
+		return n.Name + " " + typ
+	default:
+		return typ + " " + n.Name
+	}
+}
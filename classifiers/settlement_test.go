@@ -0,0 +1,139 @@
+package classifiers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSettlementName(t *testing.T) {
+	tests := []struct {
+		s     string
+		want  SettlementName
+		short string
+		full  string
+	}{
+		{"г Барнаул", SettlementName{SettlementCity, "Барнаул"}, "г Барнаул", "город Барнаул"},
+		{"г. Набережные Челны", SettlementName{SettlementCity, "Набережные Челны"}, "г Набережные Челны", "город Набережные Челны"},
+		{"г.Алейск", SettlementName{SettlementCity, "Алейск"}, "г Алейск", "город Алейск"},
+		{"с Толстая Дуброва", SettlementName{SettlementSelo, "Толстая Дуброва"}, "с Толстая Дуброва", "село Толстая Дуброва"},
+		{"рп Тальменка", SettlementName{SettlementWorkers, "Тальменка"}, "рп Тальменка", "рабочий поселок Тальменка"},
+		{"рабочий поселок Тальменка", SettlementName{SettlementWorkers, "Тальменка"}, "рп Тальменка", "рабочий поселок Тальменка"},
+		{"пгт Яблоновский", SettlementName{SettlementUrbanType, "Яблоновский"}, "пгт Яблоновский", "поселок городского типа Яблоновский"},
+		{"поселок городского типа Яблоновский", SettlementName{SettlementUrbanType, "Яблоновский"}, "пгт Яблоновский", "поселок городского типа Яблоновский"},
+		{"п Мирный", SettlementName{SettlementPosyolok, "Мирный"}, "п Мирный", "поселок Мирный"},
+		{"пос. Мирный", SettlementName{SettlementPosyolok, "Мирный"}, "п Мирный", "поселок Мирный"},
+		{"ст-ца Кущевская", SettlementName{SettlementStanitsa, "Кущевская"}, "ст-ца Кущевская", "станица Кущевская"},
+		{"ст Ольгинская", SettlementName{SettlementStation, "Ольгинская"}, "ст Ольгинская", "станция Ольгинская"},
+		{"ж/д ст Алейская", SettlementName{SettlementRailwayStation, "Алейская"}, "ж/д ст Алейская", "железнодорожная станция Алейская"},
+		{"д Ивановка", SettlementName{SettlementDerevnya, "Ивановка"}, "д Ивановка", "деревня Ивановка"},
+		{"х Красный", SettlementName{SettlementKhutor, "Красный"}, "х Красный", "хутор Красный"},
+		{"аул Хакуринохабль", SettlementName{SettlementAul, "Хакуринохабль"}, "аул Хакуринохабль", "аул Хакуринохабль"},
+		{"Алейский р-н", SettlementName{SettlementDistrict, "Алейский"}, "Алейский р-н", "Алейский район"},
+		{"Железнодорожный район", SettlementName{SettlementDistrict, "Железнодорожный"}, "Железнодорожный р-н", "Железнодорожный район"},
+		{"Кировский с/с", SettlementName{SettlementRuralCouncil, "Кировский"}, "Кировский с/с", "Кировский сельсовет"},
+		{" г Барнаул ", SettlementName{SettlementCity, "Барнаул"}, "г Барнаул", "город Барнаул"},
+		// Тип не указан
+		{"Алтайский край", SettlementName{SettlementUnknown, "Алтайский край"}, "Алтайский край", "Алтайский край"},
+		{"Толстодубровский", SettlementName{SettlementUnknown, "Толстодубровский"}, "Толстодубровский", "Толстодубровский"},
+		{"Сельсоветы Алейского р-на", SettlementName{SettlementUnknown, "Сельсоветы Алейского р-на"}, "Сельсоветы Алейского р-на", "Сельсоветы Алейского р-на"},
+		{"Городской округ Барнаул", SettlementName{SettlementUnknown, "Городской округ Барнаул"}, "Городской округ Барнаул", "Городской округ Барнаул"},
+		{"Гагарин", SettlementName{SettlementUnknown, "Гагарин"}, "Гагарин", "Гагарин"},
+		{"Г Барнаул", SettlementName{SettlementUnknown, "Г Барнаул"}, "Г Барнаул", "Г Барнаул"},
+		{"г", SettlementName{SettlementUnknown, "г"}, "г", "г"},
+		{"р-н", SettlementName{SettlementUnknown, "р-н"}, "р-н", "р-н"},
+		{"", SettlementName{}, "", ""},
+	}
+	for _, tt := range tests {
+		got := ParseSettlementName(tt.s)
+		if got != tt.want {
+			t.Errorf("ParseSettlementName(%q) = %+v, expected %+v", tt.s, got, tt.want)
+			continue
+		}
+		if s := got.String(); s != tt.short {
+			t.Errorf("%q: String() = %q, expected %q", tt.s, s, tt.short)
+		}
+		if s := got.Full(); s != tt.full {
+			t.Errorf("%q: Full() = %q, expected %q", tt.s, s, tt.full)
+		}
+	}
+}
+
+func TestSettlementType_String(t *testing.T) {
+	tests := []struct {
+		t    SettlementType
+		name string
+		abbr string
+	}{
+		{SettlementUnknown, "не указан", ""},
+		{SettlementCity, "город", "г"},
+		{SettlementWorkers, "рабочий поселок", "рп"},
+		{SettlementRuralCouncil, "сельсовет", "с/с"},
+		{SettlementType(100), "SettlementType(100)", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.t.String(); got != tt.name {
+			t.Errorf("%d: String() = %q, expected %q", int(tt.t), got, tt.name)
+		}
+		if got := tt.t.Abbr(); got != tt.abbr {
+			t.Errorf("%d: Abbr() = %q, expected %q", int(tt.t), got, tt.abbr)
+		}
+	}
+
+	// Каждый тип распознается по своему сокращению и полному наименованию
+	for typ, st := range settlementTypes {
+		for _, s := range []string{st.abbr, st.name} {
+			name := "Тест"
+			if settlementSuffix[typ] {
+				name += " " + s
+			} else {
+				name = s + " " + name
+			}
+			if got := ParseSettlementName(name); got.Type != typ || got.Name != "Тест" {
+				t.Errorf("ParseSettlementName(%q) = %+v, expected type %s", name, got, typ)
+			}
+		}
+	}
+}
+
+func TestOkatoRecord_ParseName(t *testing.T) {
+	okato := openOkatoTree(t)
+
+	tests := []struct {
+		code       string
+		settlement SettlementName
+		additional SettlementName
+	}{
+		{"01", SettlementName{SettlementUnknown, "Алтайский край"}, SettlementName{SettlementCity, "Барнаул"}},
+		{"01.201", SettlementName{SettlementDistrict, "Алейский"}, SettlementName{SettlementCity, "Алейск"}},
+		{"01.201.816", SettlementName{SettlementUnknown, "Толстодубровский"}, SettlementName{SettlementSelo, "Толстая Дуброва"}},
+		{"01.201.816.002", SettlementName{SettlementPosyolok, "Мирный"}, SettlementName{}},
+		{"01.401", SettlementName{SettlementCity, "Барнаул"}, SettlementName{}},
+	}
+	for _, tt := range tests {
+		rec, ok := okato.Lookup(tt.code)
+		if !ok {
+			t.Errorf("record %q not found", tt.code)
+			continue
+		}
+		if rec.Settlement != tt.settlement || rec.AdditionalSettlement != tt.additional {
+			t.Errorf("%s: unexpected settlement: %+v, %+v", tt.code, rec.Settlement, rec.AdditionalSettlement)
+		}
+	}
+
+	// Отбор и сортировка населенных пунктов по типу
+	var recs []*OkatoRecord
+	for rec := range okato.Descendants("01.201") {
+		if rec.Kind == OkatoSettlement {
+			recs = append(recs, rec)
+		}
+	}
+	slices.SortStableFunc(recs, func(a, b *OkatoRecord) int { return int(a.Settlement.Type - b.Settlement.Type) })
+	var names []string
+	for _, rec := range recs {
+		names = append(names, rec.Settlement.Full())
+	}
+	want := []string{"поселок Мирный", "село Малахово", "село Толстая Дуброва"}
+	if !slices.Equal(names, want) {
+		t.Errorf("unexpected settlements: %v, expected %v", names, want)
+	}
+}
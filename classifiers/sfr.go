@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/okato"
 )
 
 // Sfr - классификатор SFR_CO "Клиентские службы СФР".
@@ -14,28 +15,35 @@ import (
 // https://esnsi.gosuslugi.ru/classifiers/10991/
 type Sfr struct {
 	esnsi.Classifier[SfrRecord]
-	ByOkato   map[string]*SfrRecord   // Индекс по коду ОКАТО как в исходном справочнике (например, "92430" или "92430000000")
-	ByOkato11 map[string]*SfrRecord   // Индекс по полному коду ОКАТО 11 символов (например, "92430000000")
-	ByOkato8  map[string][]*SfrRecord // Индекс по коду ОКАТО 8 символов (например, "92430000")
-	ByOkato5  map[string][]*SfrRecord // Индекс по коду ОКАТО 5 символов (например, "92430")
-	ByOkato2  map[string][]*SfrRecord // Индекс по коду ОКАТО 2 символа (например, "92")
+	ByOkato     *esnsi.UniqueIndex[string, SfrRecord]     // Индекс по коду ОКАТО как в исходном справочнике (например, "92430" или "92430000000")
+	ByOkatoCode *esnsi.UniqueIndex[okato.Code, SfrRecord] // Индекс по коду ОКАТО в сокращенной форме (например, "92430" для "92430000000")
+	ByOkato11   *esnsi.UniqueIndex[string, SfrRecord]     // Индекс по полному коду ОКАТО 11 символов (например, "92430000000")
+	ByOkato8    *esnsi.MultiIndex[string, SfrRecord]      // Индекс по коду ОКАТО 8 символов (например, "92430000")
+	ByOkato5    *esnsi.MultiIndex[string, SfrRecord]      // Индекс по коду ОКАТО 5 символов (например, "92430")
+	ByOkato2    *esnsi.MultiIndex[string, SfrRecord]      // Индекс по коду ОКАТО 2 символа (например, "92")
+	regions     map[string]*Region                        // Субъекты РФ по коду региона СФР (например, "013" - Республика Татарстан)
+
+	// DuplicateAreas - коды ОКАТО обслуживаемых территорий, указанные в нескольких записях.
+	// В индексы ByOkato, ByOkatoCode и ByOkato11 по такому коду попадает последняя запись.
+	DuplicateAreas []SfrDuplicateArea
+}
+
+// SfrDuplicateArea - код ОКАТО обслуживаемой территории, указанный в нескольких записях Sfr.
+type SfrDuplicateArea struct {
+	Area    okato.Code   // Код ОКАТО в сокращенной форме. Пример: "92432"
+	Records []*SfrRecord // Записи с этим кодом в порядке классификатора
 }
 
 // NewSfr - создает новый классификатор Sfr из XML-данных.
+// Если код ОКАТО обслуживаемой территории указан в нескольких записях, в уникальные индексы
+// попадает последняя запись, а код перечисляется в поле DuplicateAreas.
 func NewSfr(r io.Reader) (*Sfr, error) {
-	byOkato := make(map[string]*SfrRecord)
-	byOkato11 := make(map[string]*SfrRecord)
-	byOkato8 := make(map[string][]*SfrRecord)
-	byOkato5 := make(map[string][]*SfrRecord)
-	byOkato2 := make(map[string][]*SfrRecord)
-
 	var c esnsi.Classifier[SfrRecord]
 	if err := esnsi.NewDecoder[SfrRecord](r).WithHandler(func(rec *SfrRecord) error {
 
 		// Разбираем OKATOArea на массив строк
 		areas := strings.Split(rec.OKATOArea, ",")
 
-		// Добавляем запись в индексы по кодам ОКАТО
 		for _, area := range areas {
 			// Проверяем корректность кода ОКАТО
 			area = strings.TrimSpace(area)
@@ -49,21 +57,6 @@ func NewSfr(r io.Reader) (*Sfr, error) {
 
 			// Добавляем в список обслуживаемых территорий
 			rec.OKATOAreas = append(rec.OKATOAreas, area)
-
-			// Индекс по коду ОКАТО как в исходном справочнике
-			byOkato[area] = rec
-			// Индекс по полному код ОКАТО 11 символов
-			okato11 := area + strings.Repeat("0", 11-len(area))
-			byOkato11[okato11] = rec
-			// Индекс по коду ОКАТО 8 символов
-			okato8 := okato11[:8]
-			byOkato8[okato8] = append(byOkato8[okato8], rec)
-			// Индекс по коду ОКАТО 5 символов
-			okato5 := okato11[:5]
-			byOkato5[okato5] = append(byOkato5[okato5], rec)
-			// Индекс по коду ОКАТО 2 символа
-			okato2 := okato11[:2]
-			byOkato2[okato2] = append(byOkato2[okato2], rec)
 		}
 
 		// Добавляем запись в классификатор
@@ -74,20 +67,135 @@ func NewSfr(r io.Reader) (*Sfr, error) {
 		return nil, fmt.Errorf("error decoding: %v", err)
 	}
 
-	return &Sfr{
-		Classifier: c,
-		ByOkato:    byOkato,
-		ByOkato11:  byOkato11,
-		ByOkato8:   byOkato8,
-		ByOkato5:   byOkato5,
-		ByOkato2:   byOkato2,
-	}, nil
-
+	return newSfr(c)
 }
 
 // reOKATOPlain - регулярное выражение для проверки корректности кода ОКАТО.
 var reOKATOPlain = regexp.MustCompile(`^\d{2,11}$`)
 
+// NewSfrFromSnapshot - создает новый классификатор Sfr из снимка,
+// записанного методом WriteSnapshot.
+func NewSfrFromSnapshot(r io.Reader) (*Sfr, error) {
+	var c esnsi.Classifier[SfrRecord]
+	if err := esnsi.ReadSnapshot(r, &c); err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	return newSfr(c)
+}
+
+// WriteSnapshot - записывает классификатор в w в бинарном формате снимка.
+func (s *Sfr) WriteSnapshot(w io.Writer) error {
+	return esnsi.WriteSnapshot(w, &s.Classifier)
+}
+
+// newSfr - создает классификатор Sfr из разобранных записей и строит индексы.
+func newSfr(c esnsi.Classifier[SfrRecord]) (*Sfr, error) {
+	s := &Sfr{Classifier: c, regions: make(map[string]*Region)}
+
+	// Строим индексы по кодам ОКАТО обслуживаемых территорий
+	var err error
+	if s.ByOkato, err = esnsi.NewUniqueIndexKeys(&s.Classifier, sfrLastKeys(&s.Classifier, func(rec *SfrRecord) []string { return rec.OKATOAreas })); err != nil {
+		return nil, err
+	}
+	byCode := sfrAreaKeys(func(c okato.Code) okato.Code { return c })
+	if s.ByOkatoCode, err = esnsi.NewUniqueIndexKeys(&s.Classifier, sfrLastKeys(&s.Classifier, byCode)); err != nil {
+		return nil, err
+	}
+	if s.ByOkato11, err = esnsi.NewUniqueIndexKeys(&s.Classifier, sfrLastKeys(&s.Classifier, sfrAreaKeys(okato.Code.Full11))); err != nil {
+		return nil, err
+	}
+	s.ByOkato8 = esnsi.NewMultiIndex(&s.Classifier, sfrAreaKeys(func(c okato.Code) string { return c.Full11()[:8] }))
+	s.ByOkato5 = esnsi.NewMultiIndex(&s.Classifier, sfrAreaKeys(func(c okato.Code) string { return c.Full11()[:5] }))
+	s.ByOkato2 = esnsi.NewMultiIndex(&s.Classifier, sfrAreaKeys(func(c okato.Code) string { return c.Full11()[:2] }))
+
+	// Перечисляем коды, указанные в нескольких записях
+	areas := esnsi.NewMultiIndex(&s.Classifier, byCode)
+	seen := make(map[okato.Code]bool)
+	for i := range s.Records {
+		for _, code := range byCode(&s.Records[i]) {
+			if recs := sfrDistinct(areas.Get(code)); len(recs) > 1 && !seen[code] {
+				seen[code] = true
+				s.DuplicateAreas = append(s.DuplicateAreas, SfrDuplicateArea{Area: code, Records: recs})
+			}
+		}
+	}
+
+	// Определяем субъекты РФ по кодам ОКАТО клиентских служб
+	for i := range s.Records {
+		rec := &s.Records[i]
+		if _, ok := s.regions[rec.RegionCode]; !ok && rec.RegionCode != "" {
+			if reg, ok := sfrRegion(rec); ok {
+				s.regions[rec.RegionCode] = reg
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// sfrAreaKeys - возвращает функцию ключей индекса: коды ОКАТО обслуживаемых территорий записи в форме key.
+// Некорректные коды пропускаются.
+func sfrAreaKeys[K comparable](key func(okato.Code) K) func(*SfrRecord) []K {
+	return func(rec *SfrRecord) []K {
+		keys := make([]K, 0, len(rec.OKATOAreas))
+		for _, area := range rec.OKATOAreas {
+			if code, err := okato.Parse(area); err == nil {
+				keys = append(keys, key(code))
+			}
+		}
+		return keys
+	}
+}
+
+// sfrLastKeys - возвращает функцию ключей уникального индекса: ключи keys записи, для которых
+// запись последняя среди записей классификатора c с тем же ключом. Так при повторе ключа
+// в индекс попадает последняя запись, как в словаре.
+func sfrLastKeys[K comparable](c *esnsi.Classifier[SfrRecord], keys func(*SfrRecord) []K) func(*SfrRecord) []K {
+	idx := esnsi.NewMultiIndex(c, keys)
+	return func(rec *SfrRecord) []K {
+		var res []K
+		for _, key := range keys(rec) {
+			if recs := idx.Get(key); recs[len(recs)-1] == rec {
+				res = append(res, key)
+			}
+		}
+		return res
+	}
+}
+
+// sfrDistinct - возвращает записи recs без повторов подряд идущих записей.
+func sfrDistinct(recs []*SfrRecord) []*SfrRecord {
+	res := make([]*SfrRecord, 0, len(recs))
+	for _, rec := range recs {
+		if len(res) == 0 || res[len(res)-1] != rec {
+			res = append(res, rec)
+		}
+	}
+	return res
+}
+
+// sfrRegion - определяет субъект РФ по коду ОКАТО клиентской службы
+// или, если он не задан, по первому коду ОКАТО обслуживаемой территории.
+func sfrRegion(rec *SfrRecord) (*Region, bool) {
+	if reg, ok := RegionByOkato(rec.OKATO); ok {
+		return reg, true
+	}
+	if len(rec.OKATOAreas) > 0 {
+		return RegionByOkato(rec.OKATOAreas[0])
+	}
+	return nil, false
+}
+
+// Region - возвращает субъект РФ по коду региона СФР (поле SfrRecord.RegionCode), например "013".
+//
+// Код региона СФР использует собственную нумерацию и не совпадает ни с кодом ОКАТО,
+// ни с кодом субъекта РФ, поэтому соответствие определяется по кодам ОКАТО записей справочника:
+// "013" - Республика Татарстан (ОКАТО "92", код субъекта "16").
+func (s *Sfr) Region(regionCode string) (*Region, bool) {
+	reg, ok := s.regions[regionCode]
+	return reg, ok
+}
+
 // SfrRecord - запись в классификаторе Sfr.
 type SfrRecord struct {
 	// Данные из файла справочника
@@ -1,9 +1,15 @@
 package classifiers
 
 import (
+	"bytes"
+	"errors"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/okato"
 )
 
 //goland:noinspection GoUnhandledErrorResult
@@ -117,87 +123,87 @@ func TestNewSfr(t *testing.T) {
 		}
 
 		// Проверяем индекс ByOkato
-		if len(sfr.ByOkato) != 3 {
-			t.Errorf("unexpected ByOkato length: %d, expected 3", len(sfr.ByOkato))
+		if sfr.ByOkato.Len() != 3 {
+			t.Errorf("unexpected ByOkato length: %d, expected 3", sfr.ByOkato.Len())
 		}
 
 		// Проверяем что записи есть в индексах по оригинальным кодам
-		if _, exists := sfr.ByOkato["92430"]; !exists {
+		if _, exists := sfr.ByOkato.Get("92430"); !exists {
 			t.Error("record with OKATO '92430' not found in ByOkato index")
 		}
-		if _, exists := sfr.ByOkato["92432"]; !exists {
+		if _, exists := sfr.ByOkato.Get("92432"); !exists {
 			t.Error("record with OKATO '92432' not found in ByOkato index")
 		}
-		if _, exists := sfr.ByOkato["45277592"]; !exists {
+		if _, exists := sfr.ByOkato.Get("45277592"); !exists {
 			t.Error("record with OKATO '45277592' not found in ByOkato index")
 		}
 
 		// Проверяем индекс ByOkato11
-		if len(sfr.ByOkato11) != 3 {
-			t.Errorf("unexpected ByOkato11 length: %d, expected 3", len(sfr.ByOkato11))
+		if sfr.ByOkato11.Len() != 3 {
+			t.Errorf("unexpected ByOkato11 length: %d, expected 3", sfr.ByOkato11.Len())
 		}
 
-		if _, exists := sfr.ByOkato11["92430000000"]; !exists {
+		if _, exists := sfr.ByOkato11.Get("92430000000"); !exists {
 			t.Error("record with OKATO11 '92430000000' not found in ByOkato11 index")
 		}
-		if _, exists := sfr.ByOkato11["92432000000"]; !exists {
+		if _, exists := sfr.ByOkato11.Get("92432000000"); !exists {
 			t.Error("record with OKATO11 '92432000000' not found in ByOkato11 index")
 		}
-		if _, exists := sfr.ByOkato11["45277592000"]; !exists {
+		if _, exists := sfr.ByOkato11.Get("45277592000"); !exists {
 			t.Error("record with OKATO11 '45277592000' not found in ByOkato11 index")
 		}
 
 		// Проверяем индекс ByOkato8 - теперь ожидаем 3 записи
-		if len(sfr.ByOkato8) != 3 {
-			t.Errorf("unexpected ByOkato8 length: %d, expected 3", len(sfr.ByOkato8))
+		if sfr.ByOkato8.Len() != 3 {
+			t.Errorf("unexpected ByOkato8 length: %d, expected 3", sfr.ByOkato8.Len())
 		}
 
-		records8_92430 := sfr.ByOkato8["92430000"]
+		records8_92430 := sfr.ByOkato8.Get("92430000")
 		if len(records8_92430) != 1 {
 			t.Errorf("unexpected number of records for OKATO8 '92430000': %d, expected 1", len(records8_92430))
 		}
 
-		records8_92432 := sfr.ByOkato8["92432000"]
+		records8_92432 := sfr.ByOkato8.Get("92432000")
 		if len(records8_92432) != 1 {
 			t.Errorf("unexpected number of records for OKATO8 '92432000': %d, expected 1", len(records8_92432))
 		}
 
-		records8_45277 := sfr.ByOkato8["45277592"]
+		records8_45277 := sfr.ByOkato8.Get("45277592")
 		if len(records8_45277) != 1 {
 			t.Errorf("unexpected number of records for OKATO8 '45277592': %d, expected 1", len(records8_45277))
 		}
 
 		// Проверяем индекс ByOkato5 - теперь ожидаем 3 записи
-		if len(sfr.ByOkato5) != 3 {
-			t.Errorf("unexpected ByOkato5 length: %d, expected 3", len(sfr.ByOkato5))
+		if sfr.ByOkato5.Len() != 3 {
+			t.Errorf("unexpected ByOkato5 length: %d, expected 3", sfr.ByOkato5.Len())
 		}
 
-		records5_92430 := sfr.ByOkato5["92430"]
+		records5_92430 := sfr.ByOkato5.Get("92430")
 		if len(records5_92430) != 1 {
 			t.Errorf("unexpected number of records for OKATO5 '92430': %d, expected 1", len(records5_92430))
 		}
 
-		records5_92432 := sfr.ByOkato5["92432"]
+		records5_92432 := sfr.ByOkato5.Get("92432")
 		if len(records5_92432) != 1 {
 			t.Errorf("unexpected number of records for OKATO5 '92432': %d, expected 1", len(records5_92432))
 		}
 
-		records5_45277 := sfr.ByOkato5["45277"]
+		records5_45277 := sfr.ByOkato5.Get("45277")
 		if len(records5_45277) != 1 {
 			t.Errorf("unexpected number of records for OKATO5 '45277': %d, expected 1", len(records5_45277))
 		}
 
 		// Проверяем индекс ByOkato2 - у нас 2 различных региона: 92 и 45
-		if len(sfr.ByOkato2) != 2 {
-			t.Errorf("unexpected ByOkato2 length: %d, expected 2", len(sfr.ByOkato2))
+		if sfr.ByOkato2.Len() != 2 {
+			t.Errorf("unexpected ByOkato2 length: %d, expected 2", sfr.ByOkato2.Len())
 		}
 
-		records2_92 := sfr.ByOkato2["92"]
+		records2_92 := sfr.ByOkato2.Get("92")
 		if len(records2_92) != 2 {
 			t.Errorf("unexpected number of records for OKATO2 '92': %d, expected 2", len(records2_92))
 		}
 
-		records2_45 := sfr.ByOkato2["45"]
+		records2_45 := sfr.ByOkato2.Get("45")
 		if len(records2_45) != 1 {
 			t.Errorf("unexpected number of records for OKATO2 '45': %d, expected 1", len(records2_45))
 		}
@@ -218,6 +224,21 @@ func TestNewSfr(t *testing.T) {
 		}
 	})
 
+	t.Run("OKATO area with separators", func(t *testing.T) {
+		data, err := os.ReadFile("../testdata/sfr-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to read test file: %v", err)
+		}
+		// Код в исходном справочнике записывается только цифрами
+		for _, area := range []string{"92.430", "92 430"} {
+			xml := strings.Replace(string(data), "<nsi:string>92430, 92432</nsi:string>", "<nsi:string>"+area+", 92432</nsi:string>", 1)
+			_, err = NewSfr(strings.NewReader(xml))
+			if err == nil || !strings.Contains(err.Error(), "invalid OKATO '"+area+"'") {
+				t.Errorf("%s: unexpected error: %v", area, err)
+			}
+		}
+	})
+
 	t.Run("empty OKATO area", func(t *testing.T) {
 		f, err := os.Open("../testdata/sfr-okato-area-empty_test.xml")
 		if err != nil {
@@ -262,51 +283,218 @@ func TestNewSfr(t *testing.T) {
 		}
 
 		// Проверяем индексы - должен быть только один валидный код "123456"
-		if len(sfr.ByOkato) != 1 {
-			t.Errorf("unexpected ByOkato length: %d, expected 1", len(sfr.ByOkato))
+		if sfr.ByOkato.Len() != 1 {
+			t.Errorf("unexpected ByOkato length: %d, expected 1", sfr.ByOkato.Len())
 		}
 
-		if _, exists := sfr.ByOkato["123456"]; !exists {
+		if _, exists := sfr.ByOkato.Get("123456"); !exists {
 			t.Error("record with OKATO '123456' not found in ByOkato index")
 		}
 
 		// Проверяем индекс ByOkato11 - должен содержать только один полный код
-		if len(sfr.ByOkato11) != 1 {
-			t.Errorf("unexpected ByOkato11 length: %d, expected 1", len(sfr.ByOkato11))
+		if sfr.ByOkato11.Len() != 1 {
+			t.Errorf("unexpected ByOkato11 length: %d, expected 1", sfr.ByOkato11.Len())
 		}
 
-		if _, exists := sfr.ByOkato11["12345600000"]; !exists {
+		if _, exists := sfr.ByOkato11.Get("12345600000"); !exists {
 			t.Error("record with OKATO11 '12345600000' not found in ByOkato11 index")
 		}
 
 		// Проверяем индекс ByOkato8 - должен содержать только один 8-значный код
-		if len(sfr.ByOkato8) != 1 {
-			t.Errorf("unexpected ByOkato8 length: %d, expected 1", len(sfr.ByOkato8))
+		if sfr.ByOkato8.Len() != 1 {
+			t.Errorf("unexpected ByOkato8 length: %d, expected 1", sfr.ByOkato8.Len())
 		}
 
-		records8_123456 := sfr.ByOkato8["12345600"]
+		records8_123456 := sfr.ByOkato8.Get("12345600")
 		if len(records8_123456) != 1 {
 			t.Errorf("unexpected number of records for OKATO8 '12345600': %d, expected 1", len(records8_123456))
 		}
 
 		// Проверяем индекс ByOkato5 - должен содержать только один 5-значный код
-		if len(sfr.ByOkato5) != 1 {
-			t.Errorf("unexpected ByOkato5 length: %d, expected 1", len(sfr.ByOkato5))
+		if sfr.ByOkato5.Len() != 1 {
+			t.Errorf("unexpected ByOkato5 length: %d, expected 1", sfr.ByOkato5.Len())
 		}
 
-		records5_12345 := sfr.ByOkato5["12345"]
+		records5_12345 := sfr.ByOkato5.Get("12345")
 		if len(records5_12345) != 1 {
 			t.Errorf("unexpected number of records for OKATO5 '12345': %d, expected 1", len(records5_12345))
 		}
 
 		// Проверяем индекс ByOkato2 - должен содержать только один 2-значный код
-		if len(sfr.ByOkato2) != 1 {
-			t.Errorf("unexpected ByOkato2 length: %d, expected 1", len(sfr.ByOkato2))
+		if sfr.ByOkato2.Len() != 1 {
+			t.Errorf("unexpected ByOkato2 length: %d, expected 1", sfr.ByOkato2.Len())
 		}
 
-		records2_12 := sfr.ByOkato2["12"]
+		records2_12 := sfr.ByOkato2.Get("12")
 		if len(records2_12) != 1 {
 			t.Errorf("unexpected number of records for OKATO2 '12': %d, expected 1", len(records2_12))
 		}
 	})
 }
+
+//goland:noinspection GoUnhandledErrorResult
+func TestSfrSnapshot(t *testing.T) {
+	f, err := os.Open("../testdata/sfr-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	sfr, err := NewSfr(f)
+	if err != nil {
+		t.Fatalf("failed to create SFR classifier: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = sfr.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		loaded, err := NewSfrFromSnapshot(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("failed to read snapshot: %v", err)
+		}
+		if !reflect.DeepEqual(loaded.Classifier, sfr.Classifier) {
+			t.Error("loaded classifier differs from the original")
+		}
+		if !reflect.DeepEqual(loaded.ByOkato, sfr.ByOkato) ||
+			!reflect.DeepEqual(loaded.ByOkatoCode, sfr.ByOkatoCode) ||
+			!reflect.DeepEqual(loaded.ByOkato11, sfr.ByOkato11) ||
+			!reflect.DeepEqual(loaded.ByOkato5, sfr.ByOkato5) {
+			t.Error("loaded indexes differ from the original")
+		}
+		if rec, _ := loaded.ByOkato.Get("92430"); rec == nil || rec != &loaded.Records[0] {
+			t.Error("index must point to the record in Records slice")
+		}
+	})
+
+	t.Run("wrong classifier", func(t *testing.T) {
+		_, err := NewOkatoFromSnapshot(bytes.NewReader(buf.Bytes()))
+		if !errors.Is(err, esnsi.ErrSnapshotType) {
+			t.Errorf("expected ErrSnapshotType, got %v", err)
+		}
+	})
+}
+
+//goland:noinspection GoUnhandledErrorResult
+func TestSfr_Region(t *testing.T) {
+	f, err := os.Open("../testdata/sfr-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	sfr, err := NewSfr(f)
+	if err != nil {
+		t.Fatalf("failed to create SFR classifier: %v", err)
+	}
+
+	tests := []struct {
+		regionCode string
+		okato      okato.Code
+		code       string
+	}{
+		{"013", "92", "16"},
+		{"087", "45", "77"},
+	}
+	for _, tt := range tests {
+		reg, ok := sfr.Region(tt.regionCode)
+		if !ok {
+			t.Errorf("Region(%q): region not found", tt.regionCode)
+			continue
+		}
+		if reg.Okato != tt.okato || reg.Code != tt.code {
+			t.Errorf("Region(%q) = %s/%s, expected %s/%s", tt.regionCode, reg.Okato, reg.Code, tt.okato, tt.code)
+		}
+	}
+	if _, ok := sfr.Region("16"); ok {
+		t.Error("unexpected region for subject code")
+	}
+}
+
+func TestNewSfr_duplicateArea(t *testing.T) {
+	tests := []struct {
+		name  string
+		areas []string
+	}{
+		{"same code", []string{"92432"}},
+		{"same code in other form", []string{"92432000000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := esnsi.Classifier[SfrRecord]{Records: []SfrRecord{
+				{ToSfrCode: "210", OKATOAreas: []string{"92430", "92432"}},
+				{ToSfrCode: "211", OKATOAreas: tt.areas},
+			}}
+			sfr, err := newSfr(c)
+			if err != nil {
+				t.Fatalf("failed to create SFR classifier: %v", err)
+			}
+
+			// По повторяющемуся коду в индекс попадает последняя запись
+			if rec, ok := sfr.ByOkatoCode.Get("92432"); !ok || rec.ToSfrCode != "211" {
+				t.Errorf("unexpected record for OKATO '92432': %+v", rec)
+			}
+			if rec, ok := sfr.ByOkato11.Get("92432000000"); !ok || rec.ToSfrCode != "211" {
+				t.Errorf("unexpected record for OKATO11 '92432000000': %+v", rec)
+			}
+			if rec, ok := sfr.ByOkato.Get(tt.areas[0]); !ok || rec.ToSfrCode != "211" {
+				t.Errorf("unexpected record for OKATO '%s': %+v", tt.areas[0], rec)
+			}
+			if rec, ok := sfr.ByOkatoCode.Get("92430"); !ok || rec.ToSfrCode != "210" {
+				t.Errorf("unexpected record for OKATO '92430': %+v", rec)
+			}
+			if n := len(sfr.ByOkato5.Get("92432")); n != 2 {
+				t.Errorf("unexpected number of records for OKATO5 '92432': %d, expected 2", n)
+			}
+
+			if len(sfr.DuplicateAreas) != 1 {
+				t.Fatalf("unexpected duplicate areas: %+v", sfr.DuplicateAreas)
+			}
+			dup := sfr.DuplicateAreas[0]
+			if dup.Area != "92432" || len(dup.Records) != 2 || dup.Records[0] != &sfr.Records[0] || dup.Records[1] != &sfr.Records[1] {
+				t.Errorf("unexpected duplicate area: %+v", dup)
+			}
+		})
+	}
+
+	// Повтор кода в одной записи не считается дублем
+	sfr, err := newSfr(esnsi.Classifier[SfrRecord]{Records: []SfrRecord{
+		{ToSfrCode: "210", OKATOAreas: []string{"92430", "92430000000"}},
+	}})
+	if err != nil {
+		t.Fatalf("failed to create SFR classifier: %v", err)
+	}
+	if len(sfr.DuplicateAreas) != 0 {
+		t.Errorf("unexpected duplicate areas: %+v", sfr.DuplicateAreas)
+	}
+}
+
+func TestNewSfr_rawArea(t *testing.T) {
+	c := esnsi.Classifier[SfrRecord]{Records: []SfrRecord{
+		{ToSfrCode: "210", OKATOAreas: []string{"92430000000", "92432"}},
+	}}
+	sfr, err := newSfr(c)
+	if err != nil {
+		t.Fatalf("failed to create SFR classifier: %v", err)
+	}
+
+	// ByOkato - по коду как в исходном справочнике
+	if _, ok := sfr.ByOkato.Get("92430000000"); !ok {
+		t.Error("record with OKATO '92430000000' not found in ByOkato index")
+	}
+	if _, ok := sfr.ByOkato.Get("92430"); ok {
+		t.Error("unexpected record with OKATO '92430' in ByOkato index")
+	}
+
+	// ByOkatoCode - по коду в сокращенной форме
+	for _, code := range []string{"92430", "92.430", "92430000000", "92432"} {
+		if _, ok := sfr.ByOkatoCode.Get(okato.MustParse(code)); !ok {
+			t.Errorf("record with OKATO '%s' not found in ByOkatoCode index", code)
+		}
+	}
+	if _, ok := sfr.ByOkato11.Get("92430000000"); !ok {
+		t.Error("record with OKATO11 '92430000000' not found in ByOkato11 index")
+	}
+}
@@ -0,0 +1,74 @@
+package classifiers
+
+import (
+	"iter"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// codeTree - иерархия записей классификатора с кодами ОКАТО или ОКТМО.
+// Правила определения родителя задаются функцией parents, возвращающей коды возможных родителей.
+type codeTree[K ~string, T any] struct {
+	code     func(*T) K // Код записи
+	parent   map[K]*T   // Родительские записи по коду
+	children map[K][]*T // Дочерние записи по коду
+}
+
+// newCodeTree - строит иерархию записей records.
+// Родитель записи - ближайшая существующая запись среди кандидатов parents (см. okatoParentCodes, oktmoParentCodes),
+// поэтому отсутствующие в классификаторе промежуточные записи пропускаются.
+func newCodeTree[K ~string, T any](records []T, byCode *esnsi.UniqueIndex[K, T], code func(*T) K, parents func(string) []string) *codeTree[K, T] {
+	t := &codeTree[K, T]{
+		code:     code,
+		parent:   make(map[K]*T),
+		children: make(map[K][]*T),
+	}
+	for i := range records {
+		rec := &records[i]
+		for _, c := range parents(string(code(rec))) {
+			if p, ok := byCode.Get(K(c)); ok {
+				t.parent[code(rec)] = p
+				t.children[code(p)] = append(t.children[code(p)], rec)
+				break
+			}
+		}
+	}
+	return t
+}
+
+// parentOf - возвращает родительскую запись для rec.
+func (t *codeTree[K, T]) parentOf(rec *T) (*T, bool) {
+	p, ok := t.parent[t.code(rec)]
+	return p, ok
+}
+
+// childrenOf - возвращает дочерние записи для rec в порядке записей классификатора.
+func (t *codeTree[K, T]) childrenOf(rec *T) []*T {
+	return t.children[t.code(rec)]
+}
+
+// ancestorsOf - возвращает родительские записи для rec от ближайшей до региона.
+func (t *codeTree[K, T]) ancestorsOf(rec *T) []*T {
+	var res []*T
+	for p, ok := t.parentOf(rec); ok; p, ok = t.parentOf(p) {
+		res = append(res, p)
+	}
+	return res
+}
+
+// descendantsOf - возвращает итератор по вложенным записям rec в порядке обхода в глубину.
+func (t *codeTree[K, T]) descendantsOf(rec *T) iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		t.walk(rec, yield)
+	}
+}
+
+// walk - обходит вложенные записи rec в глубину. Возвращает false, если обход прерван.
+func (t *codeTree[K, T]) walk(rec *T, yield func(*T) bool) bool {
+	for _, child := range t.childrenOf(rec) {
+		if !yield(child) || !t.walk(child, yield) {
+			return false
+		}
+	}
+	return true
+}
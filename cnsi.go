@@ -2,6 +2,7 @@ package esnsi
 
 import (
 	"encoding/xml"
+	"strings"
 )
 
 // cnsiDoc - структура для разбора XML формата ЦНСИ.
@@ -15,33 +16,75 @@ type cnsiDoc struct {
 
 // cnsiMeta - метаданные классификатора
 type cnsiMeta struct {
-	Name         string            `xml:"name,attr"`
-	Code         string            `xml:"code,attr"`
-	UID          string            `xml:"uid,attr"`
-	Version      int               `xml:"version,attr"`
-	StringAttrs  []cnsiStringAttr  `xml:"string-attribute"`
-	TextAttrs    []cnsiStringAttr  `xml:"text-attribute"`
-	IntegerAttrs []cnsiIntegerAttr `xml:"integer-attribute"`
+	Name            string     `xml:"name,attr"`
+	Code            string     `xml:"code,attr"`
+	UID             string     `xml:"uid,attr"`
+	Version         int        `xml:"version,attr"`
+	PublicID        string     `xml:"public-id,attr"`
+	TechName        string     `xml:"tech-name,attr"`
+	UpdatePeriod    int        `xml:"updatePeriod,attr"`
+	Checksum        string     `xml:"checksum,attr"`
+	KeyAttributeRef string     `xml:"key-attribute-ref,attr"`
+	Description     string     `xml:"description"`
+	Attrs           []cnsiAttr `xml:",any"`
 }
 
-// cnsiStringAttr - строковый атрибут
-type cnsiStringAttr struct {
-	UID  string `xml:"uid,attr"`
-	Name string `xml:"name,attr"`
+// fingerprint - возвращает отпечаток схемы классификатора:
+// хеш идентификаторов, наименований, технических наименований и типов атрибутов.
+func (m *cnsiMeta) fingerprint() uint64 {
+	h := newFnv64()
+	for _, attr := range m.Attrs {
+		h.add(attr.XMLName.Local, attr.UID, attr.Name, attr.TechName)
+	}
+	return uint64(h)
 }
 
-// cnsiIntegerAttr - целочисленный атрибут
-type cnsiIntegerAttr struct {
-	UID  string `xml:"uid,attr"`
-	Name string `xml:"name,attr"`
+// fnv64 - хеш FNV-1a для вычисления отпечатков без выделения памяти.
+type fnv64 uint64
+
+func newFnv64() fnv64 {
+	return 14695981039346656037
+}
+
+// add - добавляет строки в хеш, разделяя их байтом 0xff.
+func (h *fnv64) add(fields ...string) {
+	const prime64 = 1099511628211
+	for _, s := range fields {
+		for i := 0; i < len(s); i++ {
+			*h ^= fnv64(s[i])
+			*h *= prime64
+		}
+		*h ^= 0xff
+		*h *= prime64
+	}
+}
+
+// cnsiAttr - атрибут классификатора: элемент string-attribute, text-attribute, integer-attribute и т.д.
+type cnsiAttr struct {
+	XMLName  xml.Name
+	UID      string `xml:"uid,attr"`
+	Name     string `xml:"name,attr"`
+	TechName string `xml:"tech-name,attr"`
+	Required bool   `xml:"required,attr"`
+	Unique   bool   `xml:"unique,attr"`
+	Length   int    `xml:"length,attr"`
+}
+
+// attrType - возвращает тип атрибута по имени элемента
+func (a *cnsiAttr) attrType() AttrType {
+	return AttrType(strings.TrimSuffix(a.XMLName.Local, "-attribute"))
 }
 
 // cnsiRecord - запись классификатора
 type cnsiRecord struct {
 	UID      string        `xml:"uid,attr,omitempty"`
+	Action   string        `xml:"action,attr,omitempty"`
 	AttrVals []cnsiAttrVal `xml:"attribute-value"`
 }
 
+// cnsiActionDefault - действие над записью, если атрибут action не задан
+const cnsiActionDefault = "add"
+
 // cnsiAttrVal - значение атрибута записи
 type cnsiAttrVal struct {
 	AttrRef    string          `xml:"attribute-ref,attr"`
@@ -0,0 +1,29 @@
+/*
+Package dbsync загружает классификаторы ЕСНСИ в базу данных через database/sql.
+
+Загрузка выполняется в одной транзакции: записи добавляются и обновляются по ключу
+(INSERT ... ON CONFLICT), отсутствующие в классификаторе записи удаляются,
+а версия классификатора сохраняется в таблице версий (по умолчанию esnsi_meta).
+Если эта версия уже загружена, записи не изменяются.
+
+Описание таблицы задается пакетом sqlgen. Поддерживаются PostgreSQL и SQLite (3.24 и выше).
+
+# Пример использования
+
+	sfr, err := classifiers.NewSfr(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	table, err := sqlgen.NewTable(&sfr.Classifier, "sfr", sqlgen.Postgres)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Ключ - уникальный атрибут autokey (ключевой атрибут SFR_CO, используется и по умолчанию).
+	// Код клиентской службы ToSfrCode не уникален и не может быть ключом.
+	res, err := dbsync.Sync(ctx, db, &sfr.Classifier, table, dbsync.WithKeyColumn("autokey"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("version %d: %d upserted, %d deleted", res.Version, res.Upserted, res.Deleted)
+*/
+package dbsync
@@ -0,0 +1,217 @@
+package dbsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/sqlgen"
+)
+
+// DefaultMetaTable - таблица версий загруженных классификаторов по умолчанию.
+const DefaultMetaTable = "esnsi_meta"
+
+// Result - результат загрузки классификатора.
+type Result struct {
+	Version  int  // Версия классификатора
+	Skipped  bool // Версия уже загружена, записи не изменялись
+	Upserted int  // Количество добавленных и обновленных записей
+	Deleted  int  // Количество удаленных записей
+}
+
+// Option - параметр загрузки классификатора.
+type Option func(*options)
+
+// options - параметры загрузки классификатора.
+type options struct {
+	metaTable string // Таблица версий
+	keyColumn string // Ключевая колонка
+}
+
+// WithMetaTable - задает таблицу версий загруженных классификаторов.
+// По умолчанию используется DefaultMetaTable.
+func WithMetaTable(name string) Option {
+	return func(o *options) {
+		o.metaTable = name
+	}
+}
+
+// WithKeyColumn - задает ключевую колонку таблицы.
+// По умолчанию используется колонка ключевого атрибута классификатора (PRIMARY KEY).
+// На ключевой колонке должно быть ограничение PRIMARY KEY или UNIQUE.
+func WithKeyColumn(name string) Option {
+	return func(o *options) {
+		o.keyColumn = name
+	}
+}
+
+// Sync - загружает классификатор c в таблицу t базы данных db в одной транзакции:
+// добавляет и обновляет записи по ключу, удаляет отсутствующие в классификаторе записи
+// и сохраняет версию классификатора в таблице версий.
+// Если эта версия классификатора уже загружена, записи не изменяются и возвращается Result.Skipped.
+// Таблица t должна существовать в базе данных (см. sqlgen.Table.WriteCreate), таблица версий создается при необходимости.
+func Sync[T any](ctx context.Context, db *sql.DB, c *esnsi.Classifier[T], t *sqlgen.Table[T], opts ...Option) (*Result, error) {
+	if db == nil || c == nil || t == nil {
+		return nil, fmt.Errorf("nil pointer passed")
+	}
+	o := &options{metaTable: DefaultMetaTable}
+	for _, opt := range opts {
+		opt(o)
+	}
+	keyIdx := keyColumn(t, o.keyColumn)
+	if keyIdx < 0 {
+		return nil, fmt.Errorf("no key column in table %s", t.Name)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer tx.Rollback()
+
+	res, err := load(ctx, tx, c, t, keyIdx, o.metaTable)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return res, nil
+}
+
+// load - загружает классификатор в транзакции tx.
+func load[T any](ctx context.Context, tx *sql.Tx, c *esnsi.Classifier[T], t *sqlgen.Table[T], keyIdx int, metaTable string) (*Result, error) {
+	d := t.Dialect
+	res := &Result{Version: c.Version}
+	meta := d.Quote(metaTable)
+
+	// Версия классификатора
+	_, err := tx.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+meta+" ("+
+		d.Quote("table_name")+" text PRIMARY KEY, "+
+		d.Quote("code")+" text NOT NULL, "+
+		d.Quote("version")+" integer NOT NULL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table %s: %w", metaTable, err)
+	}
+	var (
+		code    string
+		version int
+	)
+	err = tx.QueryRowContext(ctx, "SELECT "+d.Quote("code")+", "+d.Quote("version")+" FROM "+meta+
+		" WHERE "+d.Quote("table_name")+" = "+d.Placeholder(1), t.Name).Scan(&code, &version)
+	switch {
+	case err == nil:
+		if code == c.Code && version == c.Version {
+			res.Skipped = true
+			return res, nil
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("failed to read version of %s: %w", t.Name, err)
+	}
+
+	// Ключи записей в базе данных
+	key := d.Quote(t.Columns[keyIdx].Name)
+	stale, err := existingKeys(ctx, tx, "SELECT "+key+" FROM "+t.QuotedName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys of %s: %w", t.Name, err)
+	}
+
+	// Добавление и обновление записей
+	upsert, err := tx.PrepareContext(ctx, upsertQuery(t, keyIdx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare upsert: %w", err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer upsert.Close()
+	for i := range c.Records {
+		vals := t.Values(&c.Records[i])
+		if _, err = upsert.ExecContext(ctx, vals...); err != nil {
+			return nil, fmt.Errorf("failed to upsert record %v: %w", vals[keyIdx], err)
+		}
+		delete(stale, fmt.Sprint(vals[keyIdx]))
+		res.Upserted++
+	}
+
+	// Удаление отсутствующих записей
+	if len(stale) > 0 {
+		del, err := tx.PrepareContext(ctx, "DELETE FROM "+t.QuotedName()+" WHERE "+key+" = "+d.Placeholder(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare delete: %w", err)
+		}
+		//goland:noinspection GoUnhandledErrorResult
+		defer del.Close()
+		for _, k := range stale {
+			if _, err = del.ExecContext(ctx, k); err != nil {
+				return nil, fmt.Errorf("failed to delete record %v: %w", k, err)
+			}
+			res.Deleted++
+		}
+	}
+
+	// Сохранение версии
+	_, err = tx.ExecContext(ctx, "INSERT INTO "+meta+" ("+
+		d.Quote("table_name")+", "+d.Quote("code")+", "+d.Quote("version")+") VALUES ("+
+		d.Placeholder(1)+", "+d.Placeholder(2)+", "+d.Placeholder(3)+") ON CONFLICT ("+d.Quote("table_name")+") DO UPDATE SET "+
+		d.Quote("code")+" = excluded."+d.Quote("code")+", "+d.Quote("version")+" = excluded."+d.Quote("version"),
+		t.Name, c.Code, c.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save version of %s: %w", t.Name, err)
+	}
+	return res, nil
+}
+
+// keyColumn - возвращает индекс ключевой колонки таблицы или -1, если она не найдена.
+func keyColumn[T any](t *sqlgen.Table[T], name string) int {
+	for i, col := range t.Columns {
+		if (name == "" && col.PrimaryKey) || (name != "" && col.Name == name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// existingKeys - возвращает ключи записей таблицы в строковом представлении.
+func existingKeys(ctx context.Context, tx *sql.Tx, query string) (map[string]any, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	keys := make(map[string]any)
+	for rows.Next() {
+		var k any
+		if err = rows.Scan(&k); err != nil {
+			return nil, err
+		}
+		if b, ok := k.([]byte); ok {
+			k = string(b)
+		}
+		keys[fmt.Sprint(k)] = k
+	}
+	return keys, rows.Err()
+}
+
+// upsertQuery - возвращает запрос добавления или обновления записи по ключевой колонке.
+func upsertQuery[T any](t *sqlgen.Table[T], keyIdx int) string {
+	d := t.Dialect
+	params := make([]string, len(t.Columns))
+	var set []string
+	for i, col := range t.Columns {
+		params[i] = d.Placeholder(i + 1)
+		if i != keyIdx {
+			set = append(set, d.Quote(col.Name)+" = excluded."+d.Quote(col.Name))
+		}
+	}
+	q := "INSERT INTO " + t.QuotedName() + " (" + t.QuotedColumns() + ") VALUES (" + strings.Join(params, ", ") + ")" +
+		" ON CONFLICT (" + d.Quote(t.Columns[keyIdx].Name) + ")"
+	if len(set) == 0 {
+		return q + " DO NOTHING"
+	}
+	return q + " DO UPDATE SET " + strings.Join(set, ", ")
+}
@@ -0,0 +1,270 @@
+package dbsync
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+	"github.com/ofstudio/go-esnsi/sqlgen"
+)
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	c := loadTestClassifier(t)
+	c.KeyAttributeRef = c.Attributes[0].UID
+	table, err := sqlgen.NewTable(c, "sfr", sqlgen.Postgres)
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	fake := newFakeDB()
+	db := sql.OpenDB(fake)
+	//goland:noinspection GoUnhandledErrorResult
+	defer db.Close()
+
+	t.Run("initial load", func(t *testing.T) {
+		res, err := Sync(ctx, db, c, table)
+		if err != nil {
+			t.Fatalf("failed to sync: %v", err)
+		}
+		if *res != (Result{Version: 55, Upserted: 4}) {
+			t.Errorf("unexpected result: %+v", res)
+		}
+		if len(fake.rows) != 4 || fake.versions["sfr"] != 55 {
+			t.Errorf("unexpected database state: %d rows, version %d", len(fake.rows), fake.versions["sfr"])
+		}
+		if !fake.executed(`INSERT INTO "sfr" ("ToSfrCode", "RegionName", "OfficeType") VALUES ($1, $2, $3) ` +
+			`ON CONFLICT ("ToSfrCode") DO UPDATE SET "RegionName" = excluded."RegionName", "OfficeType" = excluded."OfficeType"`) {
+			t.Error("expected upsert query to be executed")
+		}
+	})
+
+	t.Run("same version skipped", func(t *testing.T) {
+		fake.queries = nil
+		res, err := Sync(ctx, db, c, table)
+		if err != nil {
+			t.Fatalf("failed to sync: %v", err)
+		}
+		if !res.Skipped || res.Upserted != 0 {
+			t.Errorf("unexpected result: %+v", res)
+		}
+		if fake.executed("INSERT INTO") {
+			t.Error("expected no records to be written")
+		}
+	})
+
+	t.Run("new version", func(t *testing.T) {
+		next := *c
+		next.Version = 56
+		next.Records = append([]testRecord(nil), c.Records[1:]...)
+		next.Records[0].RegionName = "Город Москва"
+		res, err := Sync(ctx, db, &next, table)
+		if err != nil {
+			t.Fatalf("failed to sync: %v", err)
+		}
+		if *res != (Result{Version: 56, Upserted: 3, Deleted: 1}) {
+			t.Errorf("unexpected result: %+v", res)
+		}
+		if _, ok := fake.rows["210"]; ok {
+			t.Error("expected record 210 to be deleted")
+		}
+		if fake.rows["201"][1] != "Город Москва" {
+			t.Errorf("unexpected record 201: %v", fake.rows["201"])
+		}
+		if fake.versions["sfr"] != 56 {
+			t.Errorf("unexpected version: %d", fake.versions["sfr"])
+		}
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		fake.failOn = "DELETE"
+		defer func() { fake.failOn = "" }()
+		next := *c
+		next.Version = 57
+		next.Records = c.Records[2:]
+		if _, err := Sync(ctx, db, &next, table); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(fake.rows) != 3 || fake.versions["sfr"] != 56 {
+			t.Errorf("expected database to be unchanged: %d rows, version %d", len(fake.rows), fake.versions["sfr"])
+		}
+	})
+
+	t.Run("no key column", func(t *testing.T) {
+		noKey := *c
+		noKey.KeyAttributeRef = ""
+		noKeyTable, err := sqlgen.NewTable(&noKey, "sfr", sqlgen.Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		if _, err = Sync(ctx, db, &noKey, noKeyTable); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if _, err = Sync(ctx, db, &noKey, noKeyTable, WithKeyColumn("ToSfrCode")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	ToSfrCode  string `esnsi:"ToSfrCode"`
+	RegionName string `esnsi:"RegionName"`
+	OfficeType int    `esnsi:"OfficeType"`
+}
+
+// loadTestClassifier - загружает тестовый классификатор.
+//
+//goland:noinspection GoUnhandledErrorResult
+func loadTestClassifier(t *testing.T) *esnsi.Classifier[testRecord] {
+	t.Helper()
+	f, err := os.Open("../testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	c := &esnsi.Classifier[testRecord]{}
+	if err = esnsi.NewDecoder[testRecord](f).Decode(c); err != nil {
+		t.Fatalf("failed to decode classifier: %v", err)
+	}
+	return c
+}
+
+// fakeDB - база данных в памяти для тестов, реализует driver.Connector.
+// Распознает только запросы пакета dbsync; ключ записи - первая колонка.
+type fakeDB struct {
+	mu       sync.Mutex
+	rows     map[string][]driver.Value // Записи по ключу
+	versions map[string]int64          // Версии по таблицам
+	queries  []string                  // Выполненные запросы
+	failOn   string                    // Запрос с этой подстрокой завершается ошибкой
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{rows: map[string][]driver.Value{}, versions: map[string]int64{}}
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+// executed - проверяет, что выполнялся запрос, начинающийся с prefix.
+func (db *fakeDB) executed(prefix string) bool {
+	for _, q := range db.queries {
+		if strings.HasPrefix(q, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *fakeDB) exec(query string, args []driver.Value) error {
+	db.queries = append(db.queries, query)
+	if db.failOn != "" && strings.Contains(query, db.failOn) {
+		return errors.New("fake error")
+	}
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE"):
+	case strings.HasPrefix(query, `INSERT INTO "`+DefaultMetaTable+`"`):
+		db.versions[args[0].(string)] = args[2].(int64)
+	case strings.HasPrefix(query, "INSERT INTO"):
+		db.rows[fmt.Sprint(args[0])] = args
+	case strings.HasPrefix(query, "DELETE FROM"):
+		delete(db.rows, fmt.Sprint(args[0]))
+	default:
+		return fmt.Errorf("unexpected query: %s", query)
+	}
+	return nil
+}
+
+func (db *fakeDB) query(query string, args []driver.Value) (driver.Rows, error) {
+	db.queries = append(db.queries, query)
+	rows := &fakeRows{}
+	if strings.Contains(query, DefaultMetaTable) {
+		if v, ok := db.versions[args[0].(string)]; ok {
+			rows.values = [][]driver.Value{{"TestClassifier", v}}
+		}
+		return rows, nil
+	}
+	for k := range db.rows {
+		rows.values = append(rows.values, []driver.Value{k})
+	}
+	return rows, nil
+}
+
+// fakeConn - соединение с fakeDB. Транзакция откатывается восстановлением копии данных.
+type fakeConn struct {
+	db       *fakeDB
+	rows     map[string][]driver.Value
+	versions map[string]int64
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	c.rows, c.versions = maps.Clone(c.db.rows), maps.Clone(c.db.versions)
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Unlock()
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.db.rows, c.db.versions = c.rows, c.versions
+	c.db.mu.Unlock()
+	return nil
+}
+
+// fakeStmt - подготовленный запрос fakeConn.
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), s.c.db.exec(s.query, args)
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.c.db.query(s.query, args)
+}
+
+// fakeRows - результат запроса fakeStmt.
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.values) > 0 && len(r.values[0]) == 2 {
+		return []string{"code", "version"}
+	}
+	return []string{"key"}
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
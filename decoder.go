@@ -1,16 +1,20 @@
 package esnsi
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 )
 
 // Decoder - декодер классификаторов ЕСНСИ из XML формата ЦНСИ
 type Decoder[T any] struct {
-	r       io.Reader
-	handler func(*T) error
+	r        io.Reader
+	handler  func(*T) error
+	match    MatchMode
+	checksum ChecksumFunc
 }
 
 // NewDecoder - создает новый декодер классификатора для типа записей T.
@@ -27,6 +31,28 @@ func (d *Decoder[T]) WithHandler(h func(*T) error) *Decoder[T] {
 	return d
 }
 
+// WithMatchMode - задает способ сопоставления тегов esnsi с атрибутами классификатора
+// для тегов без явного префикса. По умолчанию используется MatchByName.
+//
+// Способ сопоставления можно задать для отдельного поля префиксом тега:
+//
+//	Name string `esnsi:"name:Наименование"`
+//	Data string `esnsi:"tech:additional_data"`
+//	Code string `esnsi:"uid:ccbfe331-5e63-4e6e-8bb6-d4cc7446682f"`
+func (d *Decoder[T]) WithMatchMode(m MatchMode) *Decoder[T] {
+	d.match = m
+	return d
+}
+
+// WithChecksum - включает проверку контрольной суммы документа функцией fn.
+// Если в заголовке документа объявлена контрольная сумма (атрибут checksum, отличный от "0"),
+// и она не совпадает с вычисленной, Decode возвращает ошибку ErrChecksumMismatch,
+// не изменяя классификатор. Документы без контрольной суммы не проверяются (см. ChecksumFunc).
+func (d *Decoder[T]) WithChecksum(fn ChecksumFunc) *Decoder[T] {
+	d.checksum = fn
+	return d
+}
+
 // Decode - выполняет разбор XML и возвращает классификатор.
 func (d *Decoder[T]) Decode(c *Classifier[T]) error {
 	// Проверка, что c не nil
@@ -43,121 +69,95 @@ func (d *Decoder[T]) Decode(c *Classifier[T]) error {
 		return fmt.Errorf("reader is nil")
 	}
 
+	// Если задана проверка контрольной суммы, сохраняем исходные данные документа
+	r := d.r
+	var data []byte
+	if d.checksum != nil {
+		var err error
+		if data, err = io.ReadAll(d.r); err != nil {
+			return fmt.Errorf("failed to read data: %w", err)
+		}
+		r = bytes.NewReader(data)
+	}
+
 	// Читаем XML формата ЦНСИ
 	doc := &cnsiDoc{}
-	if err := xml.NewDecoder(d.r).Decode(doc); err != nil {
+	if err := xml.NewDecoder(r).Decode(doc); err != nil {
 		return fmt.Errorf("failed to decode XML: %w", err)
 	}
 
+	// Проверяем контрольную сумму до изменения классификатора
+	if d.checksum != nil {
+		if err := verifyChecksum(d.checksum, doc.Meta.Checksum, data); err != nil {
+			return err
+		}
+	}
+
 	// Разбираем документ и заполняем классификатор
 	return d.unmarshal(c, doc)
 }
 
 func (d *Decoder[T]) unmarshal(c *Classifier[T], doc *cnsiDoc) error {
-	// Создаем индексы атрибутов
-	attrNameToRef := make(map[string]string)
-	attrRefToKind := make(map[string]reflect.Kind)
-
-	for _, attr := range doc.Meta.StringAttrs {
-		attrNameToRef[attr.Name] = attr.UID
-		attrRefToKind[attr.UID] = reflect.String
-	}
-	for _, attr := range doc.Meta.TextAttrs {
-		attrNameToRef[attr.Name] = attr.UID
-		attrRefToKind[attr.UID] = reflect.String
-	}
-	for _, attr := range doc.Meta.IntegerAttrs {
-		attrNameToRef[attr.Name] = attr.UID
-		attrRefToKind[attr.UID] = reflect.Int
-	}
-
-	// Создаем индекс полей структуры записи
-	fieldRefToIndex := make(map[string]int)
-	typeOf := reflect.TypeOf(*new(T))
-	// Проходим по всем полям структуры записи
-	// и проверяем, что для каждого поля с тегом esnsi
-	// существует соответствующий атрибут в классификаторе
-	// и что тип поля совпадает с типом атрибута
-	for i := 0; i < typeOf.NumField(); i++ {
-		field := typeOf.Field(i)
-
-		// Пропускаем поля без тега esnsi
-		attrName := field.Tag.Get("esnsi")
-		if attrName == "" {
-			continue
-		}
-
-		// Проверяем, что атрибут существует в классификаторе
-		uid, ok := attrNameToRef[attrName]
-		if !ok {
-			return fmt.Errorf("attribute %s not found in classifier", attrName)
-		}
-		kind, ok := attrRefToKind[uid]
-		if !ok {
-			return fmt.Errorf("attribute %s with Ref %s not found in classifier", attrName, uid)
-		}
-
-		// Проверяем, что тип поля совпадает с типом атрибута
-		if field.Type.Kind() != kind {
-			return fmt.Errorf("field %s has type %s, expected %s for attribute %s with Ref %s",
-				field.Name, field.Type.Kind(), kind, attrName, uid)
-		}
-		fieldRefToIndex[uid] = i
+	// Получаем план декодирования записей
+	plan, err := loadPlan(reflect.TypeOf(*new(T)), d.match, &doc.Meta)
+	if err != nil {
+		return err
 	}
 
 	// Заполняем метаданные классификатора
-	c.Name = doc.Meta.Name
-	c.Code = doc.Meta.Code
-	c.UID = doc.Meta.UID
-	c.Version = doc.Meta.Version
+	c.Meta = Meta{
+		Name:            doc.Meta.Name,
+		Code:            doc.Meta.Code,
+		UID:             doc.Meta.UID,
+		Version:         doc.Meta.Version,
+		PublicID:        doc.Meta.PublicID,
+		TechName:        doc.Meta.TechName,
+		Description:     strings.TrimSpace(doc.Meta.Description),
+		UpdatePeriod:    doc.Meta.UpdatePeriod,
+		Checksum:        doc.Meta.Checksum,
+		KeyAttributeRef: doc.Meta.KeyAttributeRef,
+		Attributes:      make([]Attribute, 0, len(doc.Meta.Attrs)),
+	}
+	for _, attr := range doc.Meta.Attrs {
+		c.Attributes = append(c.Attributes, Attribute{
+			UID:      attr.UID,
+			Name:     attr.Name,
+			TechName: attr.TechName,
+			Type:     attr.attrType(),
+			Required: attr.Required,
+			Unique:   attr.Unique,
+			Length:   attr.Length,
+		})
+	}
 
 	// Если обработчик не задан, инициализируем слайс записей
-	if d.handler != nil {
+	if d.handler == nil {
 		c.Records = make([]T, 0, len(doc.Records))
 	}
 
 	// Разбираем записи документа и добавляем их в классификатор
-	for i, docRecord := range doc.Records {
-		var record T
-		val := reflect.ValueOf(&record).Elem()
+	for i := range doc.Records {
+		// Если обработчик не задан, заполняем запись сразу в слайсе
+		var record *T
+		if d.handler == nil {
+			c.Records = append(c.Records, *new(T))
+			record = &c.Records[len(c.Records)-1]
+		} else {
+			record = new(T)
+		}
 
 		// Заполняем поля структуры записи
-		for _, attrVal := range docRecord.AttrVals {
-			fieldIndex, found := fieldRefToIndex[attrVal.AttrRef]
-			if !found {
-				continue // Поле не нужно сохранять
-			}
-			field := val.Field(fieldIndex)
-
-			switch field.Kind() {
-			case reflect.String:
-				if attrVal.StringVal != nil {
-					field.SetString(attrVal.StringVal.Val)
-				} else if attrVal.TextVal != nil {
-					field.SetString(attrVal.TextVal.Val)
-				} else {
-					return fmt.Errorf("string value for attribute %s is not set", attrVal.AttrRef)
-				}
-			case reflect.Int:
-				if attrVal.IntegerVal != nil {
-					field.SetInt(int64(attrVal.IntegerVal.Val))
-				} else {
-					return fmt.Errorf("integer value for attribute %s is not set", attrVal.AttrRef)
-				}
-			default:
-				return fmt.Errorf("unsupported field type: %s", field.Kind())
-			}
+		if err := plan.fill(reflect.ValueOf(record).Elem(), &doc.Records[i]); err != nil {
+			return err
 		}
 
-		// Если обработчик не задан, просто добавляем запись в слайс
 		if d.handler == nil {
-			c.Records = append(c.Records, record)
 			continue
 		}
 
 		// Иначе вызываем обработчик, при этом запись в слайс не добавляем
 		// (она должна быть добавлена обработчиком)
-		if err := d.handler(&record); err != nil {
+		if err := d.handler(record); err != nil {
 			return fmt.Errorf("handler error at record %d: %w", i, err)
 		}
 	}
@@ -1,6 +1,7 @@
 package esnsi
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -74,6 +75,33 @@ func TestDecoder_Decode(t *testing.T) {
 		if classifier.Version != 55 {
 			t.Errorf("unexpected Version: %d", classifier.Version)
 		}
+		if classifier.PublicID != "01-10991" {
+			t.Errorf("unexpected PublicID: %s", classifier.PublicID)
+		}
+		if classifier.TechName != "dr_2fad55ce854c4044873e7ae806cc94cb" {
+			t.Errorf("unexpected TechName: %s", classifier.TechName)
+		}
+		if classifier.Description != "TestClassifier" {
+			t.Errorf("unexpected Description: %q", classifier.Description)
+		}
+		if classifier.KeyAttributeRef != "bd4e9c55-8587-4d85-a0ae-e9c9aa9f4ec1" {
+			t.Errorf("unexpected KeyAttributeRef: %s", classifier.KeyAttributeRef)
+		}
+
+		// Проверяем атрибуты
+		if len(classifier.Attributes) != 4 {
+			t.Fatalf("unexpected number of attributes: %d", len(classifier.Attributes))
+		}
+		if a := classifier.Attributes[0]; a.Name != "ToSfrCode" || a.Type != AttrString ||
+			a.UID != "cf6621f2-438d-4744-8158-63bb963bb11f" || a.TechName != "f_cf6621f2438d4744815863bb963bb11f" {
+			t.Errorf("unexpected attribute 0: %+v", a)
+		}
+		if a := classifier.Attributes[2]; !a.Required || a.Unique || a.Length != 255 {
+			t.Errorf("unexpected attribute 2: %+v", a)
+		}
+		if a := classifier.Attributes[3]; a.Name != "OfficeType" || a.Type != AttrInteger {
+			t.Errorf("unexpected attribute 3: %+v", a)
+		}
 
 		// Проверяем записи
 		if len(classifier.Records) != 4 {
@@ -159,6 +187,205 @@ func TestDecoder_Decode(t *testing.T) {
 		}
 	})
 
+	t.Run("match by tag prefix", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[testPrefixedRecord]{}
+		if err = NewDecoder[testPrefixedRecord](f).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		if len(classifier.Records) != 4 {
+			t.Fatalf("unexpected number of records: %d", len(classifier.Records))
+		}
+		r0 := classifier.Records[0]
+		if r0.ToSfrCode != "210" {
+			t.Errorf("record 0: unexpected ToSfrCode: %s, expected 210", r0.ToSfrCode)
+		}
+		if r0.RegionName != "Республика Татарстан" {
+			t.Errorf("record 0: unexpected RegionName: %s, expected Республика Татарстан", r0.RegionName)
+		}
+		if r0.OfficeType != 2 {
+			t.Errorf("record 0: unexpected OfficeType: %d, expected 2", r0.OfficeType)
+		}
+	})
+
+	t.Run("match mode tech name", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[testTechNameRecord]{}
+		err = NewDecoder[testTechNameRecord](f).WithMatchMode(MatchByTechName).Decode(classifier)
+		if err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		if len(classifier.Records) != 4 {
+			t.Fatalf("unexpected number of records: %d", len(classifier.Records))
+		}
+		r1 := classifier.Records[1]
+		if r1.ToSfrCode != "201" {
+			t.Errorf("record 1: unexpected ToSfrCode: %s, expected 201", r1.ToSfrCode)
+		}
+		if r1.RegionName != "Москва" {
+			t.Errorf("record 1: unexpected RegionName: %s, expected Москва", r1.RegionName)
+		}
+	})
+
+	t.Run("match mode tech name with name tags", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		err = NewDecoder[testRecord](f).WithMatchMode(MatchByTechName).Decode(&Classifier[testRecord]{})
+		if err == nil {
+			t.Error("expected error, got nil")
+		} else if !strings.Contains(err.Error(), "attribute ToSfrCode not found in classifier") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("optional attribute", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[testOptionalRecord]{}
+		if err = NewDecoder[testOptionalRecord](f).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+		r0 := classifier.Records[0]
+		if r0.ToSfrCode != "210" || r0.RegionName != "Республика Татарстан" || r0.Missing != "" {
+			t.Errorf("record 0: unexpected record: %+v", r0)
+		}
+	})
+
+	t.Run("optional tag without attribute", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		err = NewDecoder[testWrongOptionalRecord](f).Decode(&Classifier[testWrongOptionalRecord]{})
+		if err == nil {
+			t.Error("expected error, got nil")
+		} else if !strings.Contains(err.Error(), "tag option 'optional' requires attribute") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("record uid and action", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-action_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[testRecordMeta]{}
+		if err = NewDecoder[testRecordMeta](f).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		if len(classifier.Records) != 4 {
+			t.Fatalf("unexpected number of records: %d", len(classifier.Records))
+		}
+		r0 := classifier.Records[0]
+		if r0.UID != "6907a38d-073f-4c3d-ba53-78aa4ae14482" {
+			t.Errorf("record 0: unexpected UID: %s", r0.UID)
+		}
+		if r0.Action != "add" {
+			t.Errorf("record 0: unexpected Action: %s, expected add", r0.Action)
+		}
+		r2 := classifier.Records[2]
+		if r2.UID != "5e14677d-6aa7-43fb-8bb9-5c758fd21918" {
+			t.Errorf("record 2: unexpected UID: %s", r2.UID)
+		}
+		if r2.Action != "update" {
+			t.Errorf("record 2: unexpected Action: %s, expected update", r2.Action)
+		}
+		if r2.ToSfrCode != "059" {
+			t.Errorf("record 2: unexpected ToSfrCode: %s, expected 059", r2.ToSfrCode)
+		}
+		if r3 := classifier.Records[3]; r3.Action != "remove" {
+			t.Errorf("record 3: unexpected Action: %s, expected remove", r3.Action)
+		}
+	})
+
+	t.Run("record uid wrong field type", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		err = NewDecoder[testWrongRecordMeta](f).Decode(&Classifier[testWrongRecordMeta]{})
+		if err == nil {
+			t.Error("expected error, got nil")
+		} else if !strings.Contains(err.Error(), "field UID has type int, expected string for tag option uid") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		src, err := os.ReadFile("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to read test file: %v", err)
+		}
+		withChecksum := func(checksum string) *strings.Reader {
+			return strings.NewReader(strings.Replace(string(src),
+				`version="55"`, `version="55" checksum="`+checksum+`"`, 1))
+		}
+		calls := 0
+		fn := func(data []byte) (string, error) {
+			calls++
+			if len(data) == 0 {
+				return "", fmt.Errorf("empty data")
+			}
+			return "12345", nil
+		}
+
+		// Контрольная сумма совпадает
+		classifier := &Classifier[testRecord]{}
+		if err = NewDecoder[testRecord](withChecksum("12345")).WithChecksum(fn).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+		if classifier.Checksum != "12345" {
+			t.Errorf("unexpected Checksum: %s", classifier.Checksum)
+		}
+		if len(classifier.Records) != 4 {
+			t.Errorf("unexpected number of records: %d", len(classifier.Records))
+		}
+
+		// Контрольная сумма не совпадает: классификатор не изменяется
+		err = NewDecoder[testRecord](withChecksum("54321")).WithChecksum(fn).Decode(classifier)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("expected ErrChecksumMismatch, got %v", err)
+		}
+		if classifier.Checksum != "12345" || len(classifier.Records) != 4 {
+			t.Error("classifier must not be changed on checksum mismatch")
+		}
+
+		// Контрольная сумма не рассчитана
+		if err = NewDecoder[testRecord](withChecksum("0")).WithChecksum(fn).Decode(classifier); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("unexpected number of checksum calls: %d, expected 2", calls)
+		}
+	})
+
 	t.Run("with custom handler", func(t *testing.T) {
 		f, err := os.Open("testdata/decoder-valid_test.xml")
 		if err != nil {
@@ -235,3 +462,40 @@ type testRecord struct {
 type testWrongFieldTypeRecord struct {
 	OfficeType string `esnsi:"OfficeType"`
 }
+
+// testPrefixedRecord - запись с явно заданными способами сопоставления атрибутов
+type testPrefixedRecord struct {
+	ToSfrCode  string `esnsi:"name:ToSfrCode"`
+	RegionName string `esnsi:"tech:f_94737d3c3d5247f5bdd5e4999fb9dbca"`
+	OfficeType int    `esnsi:"uid:bc947ef4-bc67-433a-99a3-10654ff62698"`
+}
+
+// testTechNameRecord - запись с техническими наименованиями атрибутов
+type testTechNameRecord struct {
+	ToSfrCode  string `esnsi:"f_cf6621f2438d4744815863bb963bb11f"`
+	RegionName string `esnsi:"f_94737d3c3d5247f5bdd5e4999fb9dbca"`
+}
+
+// testRecordMeta - запись с идентификатором и действием над записью
+type testRecordMeta struct {
+	UID       string `esnsi:",uid"`
+	Action    string `esnsi:",action"`
+	ToSfrCode string `esnsi:"ToSfrCode"`
+}
+
+// testOptionalRecord - запись с необязательными атрибутами
+type testOptionalRecord struct {
+	ToSfrCode  string `esnsi:"ToSfrCode"`
+	RegionName string `esnsi:"RegionName,optional"`
+	Missing    string `esnsi:"tech:missing,optional"`
+}
+
+// testWrongOptionalRecord - запись с опцией optional без атрибута
+type testWrongOptionalRecord struct {
+	Missing string `esnsi:",optional"`
+}
+
+// testWrongRecordMeta - запись с неправильным типом поля идентификатора: int вместо string
+type testWrongRecordMeta struct {
+	UID int `esnsi:",uid"`
+}
@@ -0,0 +1,205 @@
+package esnsi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffResult - результат сравнения двух версий классификатора.
+type DiffResult[K comparable, T any] struct {
+	Code       string             // Код классификатора
+	OldVersion int                // Версия старого классификатора
+	NewVersion int                // Версия нового классификатора
+	Added      []DiffRecord[K, T] // Добавленные записи (в порядке следования в новом классификаторе)
+	Removed    []DiffRecord[K, T] // Удаленные записи (в порядке следования в старом классификаторе)
+	Modified   []Modified[K, T]   // Измененные записи (в порядке следования в новом классификаторе)
+}
+
+// DiffRecord - добавленная или удаленная запись.
+type DiffRecord[K comparable, T any] struct {
+	Key    K  // Ключ записи
+	Record *T // Запись
+}
+
+// Modified - измененная запись.
+type Modified[K comparable, T any] struct {
+	Key     K             // Ключ записи
+	Old     *T            // Запись в старом классификаторе
+	New     *T            // Запись в новом классификаторе
+	Changes []FieldChange // Изменения полей
+}
+
+// FieldChange - изменение значения поля записи.
+type FieldChange struct {
+	Field string // Имя поля структуры записи. Пример: "Address"
+	Attr  string // Атрибут из тега esnsi без префикса: наименование, техническое наименование или идентификатор
+	Old   any    // Старое значение
+	New   any    // Новое значение
+}
+
+// Diff - сравнивает старую oldC и новую newC версии классификатора по ключу записей keyFn.
+// Сравниваются только поля с тегом esnsi, заполняемые значениями атрибутов.
+// Если ключ встречается в нескольких записях одной версии, возвращает ошибку *DuplicateKeyError.
+func Diff[T any, K comparable](oldC, newC *Classifier[T], keyFn func(*T) K) (*DiffResult[K, T], error) {
+	if oldC == nil || newC == nil {
+		return nil, fmt.Errorf("nil pointer passed")
+	}
+	fields, err := attrFields(reflect.TypeOf(*new(T)))
+	if err != nil {
+		return nil, err
+	}
+
+	oldIdx, err := NewUniqueIndex(oldC, keyFn)
+	if err != nil {
+		return nil, fmt.Errorf("old classifier: %w", err)
+	}
+	newIdx, err := NewUniqueIndex(newC, keyFn)
+	if err != nil {
+		return nil, fmt.Errorf("new classifier: %w", err)
+	}
+
+	res := &DiffResult[K, T]{
+		Code:       newC.Code,
+		OldVersion: oldC.Version,
+		NewVersion: newC.Version,
+	}
+
+	// Добавленные и измененные записи
+	for i := range newC.Records {
+		newRec := &newC.Records[i]
+		key := keyFn(newRec)
+		oldRec, ok := oldIdx.Get(key)
+		if !ok {
+			res.Added = append(res.Added, DiffRecord[K, T]{Key: key, Record: newRec})
+			continue
+		}
+		if changes := diffFields(fields, oldRec, newRec); len(changes) > 0 {
+			res.Modified = append(res.Modified, Modified[K, T]{Key: key, Old: oldRec, New: newRec, Changes: changes})
+		}
+	}
+
+	// Удаленные записи
+	for i := range oldC.Records {
+		oldRec := &oldC.Records[i]
+		key := keyFn(oldRec)
+		if _, ok := newIdx.Get(key); !ok {
+			res.Removed = append(res.Removed, DiffRecord[K, T]{Key: key, Record: oldRec})
+		}
+	}
+
+	return res, nil
+}
+
+// diffFields - возвращает изменения полей fields между записями a и b.
+func diffFields[T any](fields []Field, a, b *T) []FieldChange {
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	var changes []FieldChange
+	for _, f := range fields {
+		oldVal, newVal := va.Field(f.Index).Interface(), vb.Field(f.Index).Interface()
+		if oldVal != newVal {
+			changes = append(changes, FieldChange{Field: f.Name, Attr: f.Attr, Old: oldVal, New: newVal})
+		}
+	}
+	return changes
+}
+
+// Empty - возвращает true, если версии классификатора не отличаются.
+func (d *DiffResult[K, T]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// String - возвращает описание изменений в текстовом виде, например:
+//
+//	Классификатор SFR_CO: версия 54 -> 55
+//	Добавлено: 1, удалено: 0, изменено: 1
+//
+//	+ 1832
+//	~ 1831
+//	    Phone: "8 (800) 100-00-01" -> "8 (800) 100-00-02"
+func (d *DiffResult[K, T]) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Классификатор %s: версия %d -> %d\n", d.Code, d.OldVersion, d.NewVersion)
+	_, _ = fmt.Fprintf(&b, "Добавлено: %d, удалено: %d, изменено: %d\n",
+		len(d.Added), len(d.Removed), len(d.Modified))
+	if d.Empty() {
+		return b.String()
+	}
+	b.WriteString("\n")
+	for _, rec := range d.Added {
+		_, _ = fmt.Fprintf(&b, "+ %v\n", rec.Key)
+	}
+	for _, rec := range d.Removed {
+		_, _ = fmt.Fprintf(&b, "- %v\n", rec.Key)
+	}
+	for _, rec := range d.Modified {
+		_, _ = fmt.Fprintf(&b, "~ %v\n", rec.Key)
+		for _, ch := range rec.Changes {
+			_, _ = fmt.Fprintf(&b, "    %s: %#v -> %#v\n", ch.Field, ch.Old, ch.New)
+		}
+	}
+	return b.String()
+}
+
+// MarshalJSON - возвращает описание изменений в формате JSON.
+// Записи представлены объектами "имя поля - значение" по полям с тегом esnsi.
+func (d *DiffResult[K, T]) MarshalJSON() ([]byte, error) {
+	fields, err := attrFields(reflect.TypeOf(*new(T)))
+	if err != nil {
+		return nil, err
+	}
+	type jsonRecord struct {
+		Key    K              `json:"key"`
+		Record map[string]any `json:"record"`
+	}
+	type jsonChange struct {
+		Field string `json:"field"`
+		Attr  string `json:"attr"`
+		Old   any    `json:"old"`
+		New   any    `json:"new"`
+	}
+	type jsonModified struct {
+		Key     K            `json:"key"`
+		Changes []jsonChange `json:"changes"`
+	}
+	out := struct {
+		Code       string         `json:"code"`
+		OldVersion int            `json:"old_version"`
+		NewVersion int            `json:"new_version"`
+		Added      []jsonRecord   `json:"added"`
+		Removed    []jsonRecord   `json:"removed"`
+		Modified   []jsonModified `json:"modified"`
+	}{
+		Code:       d.Code,
+		OldVersion: d.OldVersion,
+		NewVersion: d.NewVersion,
+		Added:      make([]jsonRecord, 0, len(d.Added)),
+		Removed:    make([]jsonRecord, 0, len(d.Removed)),
+		Modified:   make([]jsonModified, 0, len(d.Modified)),
+	}
+	for _, rec := range d.Added {
+		out.Added = append(out.Added, jsonRecord{Key: rec.Key, Record: fieldValues(fields, rec.Record)})
+	}
+	for _, rec := range d.Removed {
+		out.Removed = append(out.Removed, jsonRecord{Key: rec.Key, Record: fieldValues(fields, rec.Record)})
+	}
+	for _, rec := range d.Modified {
+		m := jsonModified{Key: rec.Key, Changes: make([]jsonChange, 0, len(rec.Changes))}
+		for _, ch := range rec.Changes {
+			m.Changes = append(m.Changes, jsonChange{Field: ch.Field, Attr: ch.Attr, Old: ch.Old, New: ch.New})
+		}
+		out.Modified = append(out.Modified, m)
+	}
+	return json.Marshal(out)
+}
+
+// fieldValues - возвращает значения полей fields записи rec по именам полей структуры.
+func fieldValues[T any](fields []Field, rec *T) map[string]any {
+	val := reflect.ValueOf(rec).Elem()
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Name] = val.Field(f.Index).Interface()
+	}
+	return m
+}
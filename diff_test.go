@@ -0,0 +1,151 @@
+package esnsi
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldC := &Classifier[testRecord]{
+		Meta: Meta{Code: "TestClassifier", Version: 54},
+		Records: []testRecord{
+			{ToSfrCode: "210", RegionName: "Республика Татарстан", OfficeType: 2},
+			{ToSfrCode: "201", RegionName: "Москва", OfficeType: 2},
+			{ToSfrCode: "059", RegionName: "Магаданская область", OfficeType: 2},
+		},
+	}
+	newC := &Classifier[testRecord]{
+		Meta: Meta{Code: "TestClassifier", Version: 55},
+		Records: []testRecord{
+			{ToSfrCode: "210", RegionName: "Республика Татарстан", OfficeType: 2, TestField: "ignored"},
+			{ToSfrCode: "201", RegionName: "г. Москва", OfficeType: 3},
+			{ToSfrCode: "041", RegionName: "Белгородская область", OfficeType: 2},
+		},
+	}
+	keyFn := func(rec *testRecord) string { return rec.ToSfrCode }
+
+	t.Run("valid data", func(t *testing.T) {
+		d, err := Diff(oldC, newC, keyFn)
+		if err != nil {
+			t.Fatalf("failed to diff: %v", err)
+		}
+		if d.OldVersion != 54 || d.NewVersion != 55 {
+			t.Errorf("unexpected versions: %d -> %d", d.OldVersion, d.NewVersion)
+		}
+
+		if len(d.Added) != 1 || d.Added[0].Key != "041" {
+			t.Errorf("unexpected Added: %+v", d.Added)
+		}
+		if len(d.Removed) != 1 || d.Removed[0].Key != "059" {
+			t.Errorf("unexpected Removed: %+v", d.Removed)
+		}
+
+		// Поле TestField без тега esnsi не сравнивается
+		if len(d.Modified) != 1 {
+			t.Fatalf("unexpected number of modified records: %d, expected 1", len(d.Modified))
+		}
+		m := d.Modified[0]
+		if m.Key != "201" {
+			t.Errorf("unexpected modified key: %s", m.Key)
+		}
+		if len(m.Changes) != 2 {
+			t.Fatalf("unexpected number of changes: %d, expected 2", len(m.Changes))
+		}
+		if ch := m.Changes[0]; ch.Field != "RegionName" || ch.Old != "Москва" || ch.New != "г. Москва" {
+			t.Errorf("unexpected change: %+v", ch)
+		}
+		if ch := m.Changes[1]; ch.Field != "OfficeType" || ch.Old != 2 || ch.New != 3 {
+			t.Errorf("unexpected change: %+v", ch)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		d, err := Diff(oldC, oldC, keyFn)
+		if err != nil {
+			t.Fatalf("failed to diff: %v", err)
+		}
+		if !d.Empty() {
+			t.Errorf("expected empty diff, got %+v", d)
+		}
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := Diff(oldC, newC, func(rec *testRecord) int { return rec.OfficeType })
+		var dup *DuplicateKeyError
+		if !errors.As(err, &dup) {
+			t.Errorf("expected DuplicateKeyError, got %v", err)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		d, err := Diff(oldC, newC, keyFn)
+		if err != nil {
+			t.Fatalf("failed to diff: %v", err)
+		}
+		expected := `Классификатор TestClassifier: версия 54 -> 55
+Добавлено: 1, удалено: 1, изменено: 1
+
++ 041
+- 059
+~ 201
+    RegionName: "Москва" -> "г. Москва"
+    OfficeType: 2 -> 3
+`
+		if s := d.String(); s != expected {
+			t.Errorf("unexpected String:\n%s\nexpected:\n%s", s, expected)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		d, err := Diff(oldC, newC, keyFn)
+		if err != nil {
+			t.Fatalf("failed to diff: %v", err)
+		}
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		for _, s := range []string{
+			`"old_version":54`,
+			`"new_version":55`,
+			`"added":[{"key":"041","record":{"OfficeType":2,"RegionName":"Белгородская область","ToSfrCode":"041"}}]`,
+			`"removed":[{"key":"059"`,
+			`"modified":[{"key":"201","changes":[{"field":"RegionName","attr":"RegionName","old":"Москва","new":"г. Москва"},{"field":"OfficeType","attr":"OfficeType","old":2,"new":3}]}]`,
+		} {
+			if !strings.Contains(string(data), s) {
+				t.Errorf("JSON does not contain %s: %s", s, data)
+			}
+		}
+	})
+}
+
+func TestDiff_fieldName(t *testing.T) {
+	type record struct {
+		Code string `esnsi:"uid:ccbfe331-5e63-4e6e-8bb6-d4cc7446682f"`
+		Data string `esnsi:"tech:additional_data"`
+	}
+	oldC := &Classifier[record]{Records: []record{{Code: "1", Data: "old"}}}
+	newC := &Classifier[record]{Records: []record{{Code: "1", Data: "new"}}}
+	d, err := Diff(oldC, newC, func(rec *record) string { return rec.Code })
+	if err != nil {
+		t.Fatalf("failed to diff: %v", err)
+	}
+	if len(d.Modified) != 1 || len(d.Modified[0].Changes) != 1 {
+		t.Fatalf("unexpected Modified: %+v", d.Modified)
+	}
+	if ch := d.Modified[0].Changes[0]; ch.Field != "Data" || ch.Attr != "additional_data" {
+		t.Errorf("unexpected change: %+v", ch)
+	}
+	if s := d.String(); !strings.Contains(s, `    Data: "old" -> "new"`) {
+		t.Errorf("unexpected String:\n%s", s)
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if s := `{"field":"Data","attr":"additional_data","old":"old","new":"new"}`; !strings.Contains(string(data), s) {
+		t.Errorf("JSON does not contain %s: %s", s, data)
+	}
+}
@@ -12,8 +12,40 @@ Package esnsi предоставляет инструменты для рабо
 
 Classifier[T] - простой классификатор ЕСНСИ, содержащий метаданные и записи классификатора.
 
+Meta - метаданные классификатора из заголовка simple-classifier: наименование, код, версия,
+публичный идентификатор, периодичность обновления, контрольная сумма и др.
+
 Decoder[T] - декодер для преобразования XML формата ЦНСИ в структуры Go.
 
+Decoder.WithChecksum - проверка контрольной суммы документа функцией пользователя (см. ChecksumFunc).
+
+UniqueIndex[K, T] и MultiIndex[K, T] - индексы записей классификатора по произвольному ключу:
+
+	byCode, err := esnsi.NewUniqueIndex(classifier, func(rec *RegionRecord) string { return rec.Code })
+	byType := esnsi.NewMultiIndex(classifier, func(rec *RegionRecord) []int { return []int{rec.Type} })
+
+NewUniqueIndexKeys строит уникальный индекс, в котором одной записи соответствует несколько ключей.
+
+Diff - сравнение двух версий классификатора: добавленные, удаленные и измененные записи
+с перечнем изменений полей. Результат выводится в текстовом виде (String) или в JSON:
+
+	d, err := esnsi.Diff(oldClassifier, newClassifier, func(rec *RegionRecord) string { return rec.Code })
+	fmt.Print(d)
+
+WriteSnapshot и ReadSnapshot - сохранение классификатора в компактный бинарный снимок и быстрая
+загрузка из него без разбора XML. Если структура типа записей изменилась после записи снимка,
+ReadSnapshot возвращает ошибку ErrSnapshotType.
+
+Registry - потокобезопасный реестр именованных классификаторов с перезагрузкой из файла или
+из функции, открывающей поток данных. Новая версия заменяет предыдущую атомарно после полной загрузки,
+при ошибке перезагрузки сохраняется предыдущая версия:
+
+	r := esnsi.NewRegistry()
+	err := esnsi.Register(r, "okato", esnsi.FromFile("okato.xml"), classifiers.NewOkato)
+	r.OnReload(func(e esnsi.ReloadEvent) { log.Printf("reload %s: %v", e.Name, e.Err) })
+	go r.Watch(ctx, time.Minute)
+	okato, _ := esnsi.Get[classifiers.Okato](r, "okato")
+
 # Пример базового использования
 
 	package main
@@ -109,12 +141,40 @@ Decoder[T] - декодер для преобразования XML формат
 		CustomField string
 	}
 
+Классификатор без заранее описанного типа записи можно декодировать в записи DynamicRecord:
+значения атрибутов сохраняются в словаре Values, а описание атрибутов - в Classifier.Attributes.
+
 Поддерживаемые типы полей:
   - string - для string-attribute и text-attribute
   - int - для integer-attribute
 
+По умолчанию тег сопоставляется с наименованием атрибута (name). Наименования атрибутов
+могут меняться между версиями классификатора, поэтому атрибут можно указать по техническому
+наименованию (tech-name) или идентификатору (uid) с помощью префикса тега:
+
+	type MyRecord struct {
+		Name string `esnsi:"name:Наименование"`                         // по наименованию
+		Data string `esnsi:"tech:additional_data"`                      // по техническому наименованию
+		Code string `esnsi:"uid:ccbfe331-5e63-4e6e-8bb6-d4cc7446682f"` // по идентификатору
+	}
+
+Способ сопоставления для тегов без префикса задается методом Decoder.WithMatchMode.
+
+Теги ",uid" и ",action" заполняют строковые поля идентификатором записи (атрибут uid)
+и действием над записью (атрибут action: "add", "update" или "remove"; по умолчанию "add"):
+
+	type MyRecord struct {
+		UID    string `esnsi:",uid"`
+		Action string `esnsi:",action"`
+	}
+
 Декодер автоматически проверяет соответствие типов полей структуры типам атрибутов в XML
-и возвращает ошибку при несоответствии.
+и возвращает ошибку при несоответствии или отсутствии атрибута. Опция ",optional" допускает
+отсутствие атрибута в классификаторе, поле при этом остается пустым:
+
+	type MyRecord struct {
+		Okato string `esnsi:"ОКАТО,optional"`
+	}
 
 # Специализированные классификаторы
 
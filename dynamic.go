@@ -0,0 +1,93 @@
+package esnsi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DynamicRecord - запись классификатора произвольной структуры.
+// Позволяет декодировать классификатор без заранее описанного типа записи:
+//
+//	c := &esnsi.Classifier[esnsi.DynamicRecord]{}
+//	err := esnsi.NewDecoder[esnsi.DynamicRecord](r).Decode(c)
+//
+// Описание атрибутов доступно в c.Attributes.
+type DynamicRecord struct {
+	UID    string         // Идентификатор записи
+	Action string         // Действие над записью: "add", "update" или "remove"
+	Values map[string]any // Значения атрибутов (string или int) по наименованию атрибута
+}
+
+// dynamicRecordType - тип DynamicRecord
+var dynamicRecordType = reflect.TypeOf(DynamicRecord{})
+
+// dynamicField - атрибут, значение которого сохраняется в DynamicRecord.
+type dynamicField struct {
+	key  string       // Ключ в DynamicRecord.Values
+	kind reflect.Kind // Тип значения
+}
+
+// compileDynamicPlan - компилирует план декодирования записей DynamicRecord.
+// Ключом значения атрибута является наименование, техническое наименование
+// или идентификатор атрибута, в зависимости от способа сопоставления match.
+func compileDynamicPlan(match MatchMode, meta *cnsiMeta) (*decodePlan, error) {
+	p := &decodePlan{dynamic: make(map[string]dynamicField), uidIndex: -1, actionIndex: -1}
+	for _, attr := range meta.Attrs {
+		var kind reflect.Kind
+		switch attr.attrType() {
+		case AttrString, AttrText:
+			kind = reflect.String
+		case AttrInteger:
+			kind = reflect.Int
+		default:
+			continue // Атрибуты других типов не поддерживаются
+		}
+		var key string
+		switch match {
+		case MatchByName:
+			key = attr.Name
+		case MatchByTechName:
+			key = attr.TechName
+		case MatchByUID:
+			key = attr.UID
+		default:
+			return nil, fmt.Errorf("unsupported match mode %s", match)
+		}
+		p.dynamic[attr.UID] = dynamicField{key: key, kind: kind}
+	}
+	return p, nil
+}
+
+// fillDynamic - заполняет запись DynamicRecord значениями из записи документа.
+func (p *decodePlan) fillDynamic(rec *DynamicRecord, docRecord *cnsiRecord) error {
+	rec.UID = docRecord.UID
+	rec.Action = docRecord.Action
+	if rec.Action == "" {
+		rec.Action = cnsiActionDefault
+	}
+	rec.Values = make(map[string]any, len(p.dynamic))
+	for i := range docRecord.AttrVals {
+		attrVal := &docRecord.AttrVals[i]
+		f, found := p.dynamic[attrVal.AttrRef]
+		if !found {
+			continue
+		}
+		switch f.kind {
+		case reflect.String:
+			switch {
+			case attrVal.StringVal != nil:
+				rec.Values[f.key] = attrVal.StringVal.Val
+			case attrVal.TextVal != nil:
+				rec.Values[f.key] = attrVal.TextVal.Val
+			default:
+				return fmt.Errorf("string value for attribute %s is not set", attrVal.AttrRef)
+			}
+		case reflect.Int:
+			if attrVal.IntegerVal == nil {
+				return fmt.Errorf("integer value for attribute %s is not set", attrVal.AttrRef)
+			}
+			rec.Values[f.key] = attrVal.IntegerVal.Val
+		}
+	}
+	return nil
+}
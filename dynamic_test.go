@@ -0,0 +1,84 @@
+package esnsi
+
+import (
+	"os"
+	"testing"
+)
+
+//goland:noinspection GoUnhandledErrorResult
+func TestDecoder_DecodeDynamic(t *testing.T) {
+	t.Run("valid data", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[DynamicRecord]{}
+		if err = NewDecoder[DynamicRecord](f).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		if len(classifier.Attributes) != 4 {
+			t.Fatalf("unexpected number of attributes: %d", len(classifier.Attributes))
+		}
+		if len(classifier.Records) != 4 {
+			t.Fatalf("unexpected number of records: %d", len(classifier.Records))
+		}
+
+		r0 := classifier.Records[0]
+		if r0.UID != "6907a38d-073f-4c3d-ba53-78aa4ae14482" {
+			t.Errorf("record 0: unexpected UID: %s", r0.UID)
+		}
+		if r0.Action != "add" {
+			t.Errorf("record 0: unexpected Action: %s", r0.Action)
+		}
+		if len(r0.Values) != 4 {
+			t.Errorf("record 0: unexpected number of values: %d", len(r0.Values))
+		}
+		if v := r0.Values["ToSfrCode"]; v != "210" {
+			t.Errorf("record 0: unexpected ToSfrCode: %v", v)
+		}
+		if v := r0.Values["RegionName"]; v != "Республика Татарстан" {
+			t.Errorf("record 0: unexpected RegionName: %v", v)
+		}
+		if v := r0.Values["OfficeType"]; v != 2 {
+			t.Errorf("record 0: unexpected OfficeType: %v", v)
+		}
+	})
+
+	t.Run("record action", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-action_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[DynamicRecord]{}
+		if err = NewDecoder[DynamicRecord](f).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		for i, want := range []string{"add", "add", "update", "remove"} {
+			if r := classifier.Records[i]; r.Action != want {
+				t.Errorf("record %d: unexpected Action: %s, expected %s", i, r.Action, want)
+			}
+		}
+	})
+
+	t.Run("match mode tech name", func(t *testing.T) {
+		f, err := os.Open("testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer f.Close()
+
+		classifier := &Classifier[DynamicRecord]{}
+		if err = NewDecoder[DynamicRecord](f).WithMatchMode(MatchByTechName).Decode(classifier); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+		if v := classifier.Records[1].Values["f_94737d3c3d5247f5bdd5e4999fb9dbca"]; v != "Москва" {
+			t.Errorf("record 1: unexpected RegionName: %v", v)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// CSV - выгружает записи классификатора c в w в формате CSV (RFC 4180):
+// разделитель - запятая, окончание строк - CRLF, первая строка - названия колонок.
+func CSV[T any](w io.Writer, c *esnsi.Classifier[T], opts ...Option) error {
+	o := newOptions(opts)
+	t, err := newTable(c, o)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if o.bom {
+		_, _ = bw.WriteString(bom)
+	}
+
+	cw := csv.NewWriter(bw)
+	cw.UseCRLF = true
+	if err = cw.Write(t.columns); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	record := make([]string, len(t.columns))
+	for i := 0; i < t.len; i++ {
+		for j, v := range t.row(i) {
+			record[j] = formatValue(v)
+		}
+		if err = cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write record %d: %w", i, err)
+		}
+	}
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+
+	if err = bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+func TestCSV(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+
+	t.Run("all columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := CSV(&buf, c); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		expected := "ToSfrCode,RegionName,OfficeType\r\n" +
+			"210,Республика Татарстан,2\r\n" +
+			"201,Москва,2\r\n" +
+			"059,Магаданская область,2\r\n" +
+			"041,Белгородская область,2\r\n"
+		if buf.String() != expected {
+			t.Errorf("unexpected CSV:\n%q\nexpected:\n%q", buf.String(), expected)
+		}
+	})
+
+	t.Run("columns and BOM", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := CSV(&buf, c, WithColumns("RegionName", "ToSfrCode"), WithBOM()); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "\xef\xbb\xbfRegionName,ToSfrCode\r\nРеспублика Татарстан,210\r\n") {
+			t.Errorf("unexpected CSV: %q", buf.String())
+		}
+	})
+
+	t.Run("quoting", func(t *testing.T) {
+		q := &esnsi.Classifier[testRecord]{Records: []testRecord{{ToSfrCode: "1", RegionName: "Город \"N\", район"}}}
+		var buf bytes.Buffer
+		if err := CSV(&buf, q, WithColumns("RegionName")); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if expected := "RegionName\r\n\"Город \"\"N\"\", район\"\r\n"; buf.String() != expected {
+			t.Errorf("unexpected CSV: %q, expected %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		err := CSV(&bytes.Buffer{}, c, WithColumns("Unknown"))
+		if err == nil {
+			t.Error("expected error, got nil")
+		} else if !strings.Contains(err.Error(), "unknown column 'Unknown'") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("dynamic", func(t *testing.T) {
+		d := loadTestClassifier[esnsi.DynamicRecord](t)
+		var buf bytes.Buffer
+		if err := CSV(&buf, d); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "ToSfrCode,RegionCode,RegionName,OfficeType\r\n210,013,Республика Татарстан,2\r\n") {
+			t.Errorf("unexpected CSV: %q", buf.String())
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	UID        string `esnsi:",uid"`
+	ToSfrCode  string `esnsi:"ToSfrCode"`
+	RegionName string `esnsi:"RegionName"`
+	OfficeType int    `esnsi:"OfficeType"`
+	TestField  string
+}
+
+// loadTestClassifier - загружает тестовый классификатор с записями типа T.
+//
+//goland:noinspection GoUnhandledErrorResult
+func loadTestClassifier[T any](t *testing.T) *esnsi.Classifier[T] {
+	t.Helper()
+	f, err := os.Open("../testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	c := &esnsi.Classifier[T]{}
+	if err = esnsi.NewDecoder[T](f).Decode(c); err != nil {
+		t.Fatalf("failed to decode classifier: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,47 @@
+/*
+Package export предоставляет выгрузку классификаторов ЕСНСИ в форматы JSON, NDJSON и CSV.
+
+Выгружаются поля записей с тегом esnsi; названия колонок соответствуют атрибутам из тегов.
+Для классификаторов с записями esnsi.DynamicRecord колонки соответствуют ключам значений атрибутов.
+
+# Форматы
+
+  - JSON - метаданные классификатора и массив записей с отступами
+  - NDJSON - по одной записи в строке, без метаданных
+  - CSV - по RFC 4180, с заголовком из названий колонок
+
+# Пример использования
+
+	package main
+
+	import (
+		"log"
+		"os"
+
+		"github.com/ofstudio/go-esnsi/classifiers"
+		"github.com/ofstudio/go-esnsi/export"
+	)
+
+	func main() {
+		file, err := os.Open("SFR_CO_55UTF-8.xml")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+
+		sfr, err := classifiers.NewSfr(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// Выгружаем в CSV для Excel только нужные колонки
+		err = export.CSV(os.Stdout, &sfr.Classifier,
+			export.WithColumns("ToSfrCode", "ToSfrName", "Address", "Phone"),
+			export.WithBOM(),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+*/
+package export
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// Option - параметр выгрузки.
+type Option func(*options)
+
+// options - параметры выгрузки.
+type options struct {
+	columns []string // Выбранные колонки
+	bom     bool     // Добавлять BOM UTF-8
+}
+
+// WithColumns - задает колонки для выгрузки и их порядок.
+// По умолчанию выгружаются все колонки в порядке следования полей в структуре записи.
+func WithColumns(columns ...string) Option {
+	return func(o *options) {
+		o.columns = columns
+	}
+}
+
+// WithBOM - добавляет в начало CSV метку порядка байтов UTF-8,
+// чтобы Excel правильно определял кодировку. Для JSON и NDJSON не применяется:
+// RFC 8259 запрещает BOM в JSON.
+func WithBOM() Option {
+	return func(o *options) {
+		o.bom = true
+	}
+}
+
+// bom - метка порядка байтов UTF-8
+const bom = "\xef\xbb\xbf"
+
+// table - табличное представление записей классификатора.
+type table struct {
+	columns []string          // Колонки
+	len     int               // Количество записей
+	row     func(i int) []any // Значения колонок записи i
+}
+
+// newTable - строит табличное представление записей классификатора c с параметрами o.
+func newTable[T any](c *esnsi.Classifier[T], o *options) (*table, error) {
+	if c == nil {
+		return nil, fmt.Errorf("nil pointer passed")
+	}
+
+	// Определяем доступные колонки и функцию получения значения колонки
+	var available []string
+	var value func(rec *T, col int) any
+	if dyn, ok := any(c).(*esnsi.Classifier[esnsi.DynamicRecord]); ok {
+		available = dynamicColumns(dyn)
+		value = func(rec *T, col int) any {
+			return any(rec).(*esnsi.DynamicRecord).Values[available[col]]
+		}
+	} else {
+		fields, err := esnsi.Fields[T]()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			available = append(available, f.Attr)
+		}
+		value = func(rec *T, col int) any {
+			return fields[col].Value(rec)
+		}
+	}
+
+	// Выбираем колонки
+	selected := make([]int, 0, len(available))
+	columns := available
+	if o.columns != nil {
+		columns = o.columns
+		for _, name := range o.columns {
+			i := slices.Index(available, name)
+			if i < 0 {
+				return nil, fmt.Errorf("unknown column '%s'", name)
+			}
+			selected = append(selected, i)
+		}
+	} else {
+		for i := range available {
+			selected = append(selected, i)
+		}
+	}
+
+	return &table{
+		columns: columns,
+		len:     len(c.Records),
+		row: func(i int) []any {
+			rec := &c.Records[i]
+			row := make([]any, len(selected))
+			for j, col := range selected {
+				row[j] = value(rec, col)
+			}
+			return row
+		},
+	}, nil
+}
+
+// dynamicColumns - возвращает колонки классификатора с записями DynamicRecord:
+// ключи значений атрибутов в порядке следования атрибутов в заголовке,
+// затем прочие ключи в порядке сортировки.
+func dynamicColumns(c *esnsi.Classifier[esnsi.DynamicRecord]) []string {
+	keys := make(map[string]bool)
+	for i := range c.Records {
+		for key := range c.Records[i].Values {
+			keys[key] = true
+		}
+	}
+
+	var columns []string
+	for _, attr := range c.Attributes {
+		for _, key := range []string{attr.Name, attr.TechName, attr.UID} {
+			if keys[key] {
+				columns = append(columns, key)
+				delete(keys, key)
+				break
+			}
+		}
+	}
+	rest := make([]string, 0, len(keys))
+	for key := range keys {
+		rest = append(rest, key)
+	}
+	slices.Sort(rest)
+	return append(columns, rest...)
+}
+
+// newOptions - применяет параметры выгрузки.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// formatValue - возвращает строковое представление значения для CSV.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
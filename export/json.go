@@ -0,0 +1,113 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// JSON - выгружает классификатор c в w в формате JSON с отступами:
+//
+//	{
+//	  "meta": {"name": "...", "code": "...", "version": 55, ...},
+//	  "records": [
+//	    {"ToSfrCode": "210", "RegionName": "Республика Татарстан", ...},
+//	    ...
+//	  ]
+//	}
+//
+// Поля записей выводятся в порядке колонок.
+func JSON[T any](w io.Writer, c *esnsi.Classifier[T], opts ...Option) error {
+	t, err := newTable(c, newOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	meta, err := json.MarshalIndent(c.Meta, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode meta: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("{\n  \"meta\": ")
+	_, _ = bw.Write(meta)
+	_, _ = bw.WriteString(",\n  \"records\": [")
+
+	var compact, indented bytes.Buffer
+	for i := 0; i < t.len; i++ {
+		compact.Reset()
+		indented.Reset()
+		if err = t.writeObject(&compact, i); err != nil {
+			return err
+		}
+		if err = json.Indent(&indented, compact.Bytes(), "    ", "  "); err != nil {
+			return fmt.Errorf("failed to encode record %d: %w", i, err)
+		}
+		if i > 0 {
+			_ = bw.WriteByte(',')
+		}
+		_, _ = bw.WriteString("\n    ")
+		_, _ = indented.WriteTo(bw)
+	}
+	if t.len > 0 {
+		_, _ = bw.WriteString("\n  ")
+	}
+	_, _ = bw.WriteString("]\n}\n")
+
+	if err = bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// NDJSON - выгружает записи классификатора c в w в формате NDJSON:
+// по одному JSON-объекту на строку, без метаданных.
+func NDJSON[T any](w io.Writer, c *esnsi.Classifier[T], opts ...Option) error {
+	t, err := newTable(c, newOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf bytes.Buffer
+	for i := 0; i < t.len; i++ {
+		buf.Reset()
+		if err = t.writeObject(&buf, i); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, _ = buf.WriteTo(bw)
+	}
+
+	if err = bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// writeObject - записывает запись i в buf в виде JSON-объекта с полями в порядке колонок.
+func (t *table) writeObject(buf *bytes.Buffer, i int) error {
+	buf.WriteByte('{')
+	for j, v := range t.row(i) {
+		if j > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(t.columns[j])
+		if err != nil {
+			return fmt.Errorf("failed to encode column '%s': %w", t.columns[j], err)
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode record %d column '%s': %w", i, t.columns[j], err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return nil
+}
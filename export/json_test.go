@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+func TestJSON(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+
+	t.Run("valid data", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := JSON(&buf, c, WithColumns("ToSfrCode", "OfficeType")); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+
+		var out struct {
+			Meta    esnsi.Meta       `json:"meta"`
+			Records []map[string]any `json:"records"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if out.Meta.Code != "TestClassifier" || out.Meta.Version != 55 || out.Meta.PublicID != "01-10991" {
+			t.Errorf("unexpected meta: %+v", out.Meta)
+		}
+		if len(out.Records) != 4 {
+			t.Fatalf("unexpected number of records: %d", len(out.Records))
+		}
+		if r := out.Records[1]; len(r) != 2 || r["ToSfrCode"] != "201" || r["OfficeType"] != float64(2) {
+			t.Errorf("unexpected record 1: %v", r)
+		}
+
+		// Поля записи выводятся в порядке колонок, с отступами
+		if !strings.Contains(buf.String(), "    {\n      \"ToSfrCode\": \"210\",\n      \"OfficeType\": 2\n    }") {
+			t.Errorf("unexpected JSON format:\n%s", buf.String())
+		}
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := JSON(&buf, &esnsi.Classifier[testRecord]{}); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if !json.Valid(buf.Bytes()) || !strings.Contains(buf.String(), `"records": []`) {
+			t.Errorf("unexpected JSON:\n%s", buf.String())
+		}
+	})
+}
+
+func TestNDJSON(t *testing.T) {
+	c := loadTestClassifier[esnsi.DynamicRecord](t)
+
+	var buf bytes.Buffer
+	if err := NDJSON(&buf, c, WithColumns("RegionName", "OfficeType")); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	expected := `{"RegionName":"Республика Татарстан","OfficeType":2}
+{"RegionName":"Москва","OfficeType":2}
+{"RegionName":"Магаданская область","OfficeType":2}
+{"RegionName":"Белгородская область","OfficeType":2}
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected NDJSON:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,123 @@
+package esnsi
+
+import (
+	"fmt"
+	"iter"
+)
+
+// DuplicateKeyError - ошибка построения уникального индекса: ключ встречается в нескольких записях.
+type DuplicateKeyError struct {
+	Key    any // Повторяющийся ключ
+	Record int // Номер записи в классификаторе, в которой ключ встретился повторно
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key '%v' at record %d", e.Key, e.Record)
+}
+
+// UniqueIndex - уникальный индекс записей классификатора: один ключ - одна запись.
+// Индекс хранит указатели на записи в слайсе Records классификатора.
+type UniqueIndex[K comparable, T any] struct {
+	m map[K]*T
+}
+
+// NewUniqueIndex - строит уникальный индекс записей классификатора c по ключу keyFn.
+// Если ключ встречается в нескольких записях, возвращает ошибку *DuplicateKeyError.
+func NewUniqueIndex[K comparable, T any](c *Classifier[T], keyFn func(*T) K) (*UniqueIndex[K, T], error) {
+	m := make(map[K]*T, len(c.Records))
+	for i := range c.Records {
+		rec := &c.Records[i]
+		key := keyFn(rec)
+		if _, exists := m[key]; exists {
+			return nil, &DuplicateKeyError{Key: key, Record: i}
+		}
+		m[key] = rec
+	}
+	return &UniqueIndex[K, T]{m: m}, nil
+}
+
+// NewUniqueIndexKeys - строит уникальный индекс записей классификатора c по ключам keysFn:
+// одной записи может соответствовать несколько ключей, каждому ключу - одна запись.
+// Повторяющиеся ключи одной записи пропускаются. Если ключ встречается в нескольких записях,
+// возвращает ошибку *DuplicateKeyError.
+func NewUniqueIndexKeys[K comparable, T any](c *Classifier[T], keysFn func(*T) []K) (*UniqueIndex[K, T], error) {
+	m := make(map[K]*T, len(c.Records))
+	for i := range c.Records {
+		rec := &c.Records[i]
+		for _, key := range keysFn(rec) {
+			if prev, exists := m[key]; exists {
+				if prev == rec {
+					continue
+				}
+				return nil, &DuplicateKeyError{Key: key, Record: i}
+			}
+			m[key] = rec
+		}
+	}
+	return &UniqueIndex[K, T]{m: m}, nil
+}
+
+// Get - возвращает запись по ключу.
+func (idx *UniqueIndex[K, T]) Get(key K) (*T, bool) {
+	rec, ok := idx.m[key]
+	return rec, ok
+}
+
+// All - возвращает итератор по всем ключам и записям индекса в произвольном порядке.
+func (idx *UniqueIndex[K, T]) All() iter.Seq2[K, *T] {
+	return func(yield func(K, *T) bool) {
+		for key, rec := range idx.m {
+			if !yield(key, rec) {
+				return
+			}
+		}
+	}
+}
+
+// Len - возвращает количество ключей в индексе.
+func (idx *UniqueIndex[K, T]) Len() int {
+	return len(idx.m)
+}
+
+// MultiIndex - неуникальный индекс записей классификатора:
+// одному ключу может соответствовать несколько записей, а одной записи - несколько ключей.
+// Индекс хранит указатели на записи в слайсе Records классификатора.
+type MultiIndex[K comparable, T any] struct {
+	m map[K][]*T
+}
+
+// NewMultiIndex - строит неуникальный индекс записей классификатора c по ключам keysFn.
+// Записи, для которых keysFn возвращает пустой слайс, в индекс не попадают.
+// Записи по каждому ключу хранятся в порядке следования в классификаторе; если keysFn
+// возвращает ключ несколько раз, запись добавляется по этому ключу столько же раз.
+func NewMultiIndex[K comparable, T any](c *Classifier[T], keysFn func(*T) []K) *MultiIndex[K, T] {
+	m := make(map[K][]*T)
+	for i := range c.Records {
+		rec := &c.Records[i]
+		for _, key := range keysFn(rec) {
+			m[key] = append(m[key], rec)
+		}
+	}
+	return &MultiIndex[K, T]{m: m}
+}
+
+// Get - возвращает записи по ключу.
+func (idx *MultiIndex[K, T]) Get(key K) []*T {
+	return idx.m[key]
+}
+
+// All - возвращает итератор по всем ключам и записям индекса в произвольном порядке.
+func (idx *MultiIndex[K, T]) All() iter.Seq2[K, []*T] {
+	return func(yield func(K, []*T) bool) {
+		for key, recs := range idx.m {
+			if !yield(key, recs) {
+				return
+			}
+		}
+	}
+}
+
+// Len - возвращает количество ключей в индексе.
+func (idx *MultiIndex[K, T]) Len() int {
+	return len(idx.m)
+}
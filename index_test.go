@@ -0,0 +1,147 @@
+package esnsi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewUniqueIndex(t *testing.T) {
+	c := &Classifier[testRecord]{Records: []testRecord{
+		{ToSfrCode: "210", RegionName: "Республика Татарстан"},
+		{ToSfrCode: "201", RegionName: "Москва"},
+		{ToSfrCode: "059", RegionName: "Магаданская область"},
+	}}
+
+	t.Run("valid data", func(t *testing.T) {
+		idx, err := NewUniqueIndex(c, func(rec *testRecord) string { return rec.ToSfrCode })
+		if err != nil {
+			t.Fatalf("failed to build index: %v", err)
+		}
+		if idx.Len() != 3 {
+			t.Errorf("unexpected Len: %d, expected 3", idx.Len())
+		}
+
+		rec, ok := idx.Get("201")
+		if !ok {
+			t.Fatal("record '201' not found")
+		}
+		if rec != &c.Records[1] {
+			t.Error("index must point to the record in Records slice")
+		}
+		if _, ok = idx.Get("999"); ok {
+			t.Error("unexpected record '999'")
+		}
+
+		n := 0
+		for key, rec := range idx.All() {
+			if rec.ToSfrCode != key {
+				t.Errorf("unexpected record %s for key %s", rec.ToSfrCode, key)
+			}
+			n++
+		}
+		if n != 3 {
+			t.Errorf("unexpected number of iterated records: %d, expected 3", n)
+		}
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := NewUniqueIndex(c, func(rec *testRecord) int { return rec.OfficeType })
+		var dup *DuplicateKeyError
+		if !errors.As(err, &dup) {
+			t.Fatalf("expected DuplicateKeyError, got %v", err)
+		}
+		if dup.Key != 0 || dup.Record != 1 {
+			t.Errorf("unexpected duplicate: key %v, record %d", dup.Key, dup.Record)
+		}
+		if !strings.Contains(err.Error(), "duplicate key '0' at record 1") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestNewUniqueIndexKeys(t *testing.T) {
+	keys := func(rec *testRecord) []string {
+		if rec.TestField == "" {
+			return nil
+		}
+		return strings.Split(rec.TestField, ",")
+	}
+
+	t.Run("valid data", func(t *testing.T) {
+		c := &Classifier[testRecord]{Records: []testRecord{
+			{ToSfrCode: "210", TestField: "92430,92432,92430"},
+			{ToSfrCode: "201", TestField: "45277"},
+			{ToSfrCode: "059"},
+		}}
+		idx, err := NewUniqueIndexKeys(c, keys)
+		if err != nil {
+			t.Fatalf("failed to build index: %v", err)
+		}
+		if idx.Len() != 3 {
+			t.Errorf("unexpected Len: %d, expected 3", idx.Len())
+		}
+		for key, want := range map[string]*testRecord{"92430": &c.Records[0], "92432": &c.Records[0], "45277": &c.Records[1]} {
+			if rec, ok := idx.Get(key); !ok || rec != want {
+				t.Errorf("unexpected record for key %s: %v", key, rec)
+			}
+		}
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		c := &Classifier[testRecord]{Records: []testRecord{
+			{ToSfrCode: "210", TestField: "92430,92432"},
+			{ToSfrCode: "211", TestField: "92433,92432"},
+		}}
+		_, err := NewUniqueIndexKeys(c, keys)
+		var dup *DuplicateKeyError
+		if !errors.As(err, &dup) {
+			t.Fatalf("expected DuplicateKeyError, got %v", err)
+		}
+		if dup.Key != "92432" || dup.Record != 1 {
+			t.Errorf("unexpected duplicate: key %v, record %d", dup.Key, dup.Record)
+		}
+	})
+}
+
+func TestNewMultiIndex(t *testing.T) {
+	c := &Classifier[testRecord]{Records: []testRecord{
+		{ToSfrCode: "210", RegionName: "Республика Татарстан", TestField: "92430,92432"},
+		{ToSfrCode: "211", RegionName: "Республика Татарстан", TestField: "92430,92430"},
+		{ToSfrCode: "201", RegionName: "Москва"},
+	}}
+
+	idx := NewMultiIndex(c, func(rec *testRecord) []string {
+		if rec.TestField == "" {
+			return nil
+		}
+		return strings.Split(rec.TestField, ",")
+	})
+
+	if idx.Len() != 2 {
+		t.Errorf("unexpected Len: %d, expected 2", idx.Len())
+	}
+
+	// Ключ, повторяющийся в записи, добавляет запись повторно
+	recs := idx.Get("92430")
+	if len(recs) != 3 {
+		t.Fatalf("unexpected number of records for '92430': %d, expected 3", len(recs))
+	}
+	if recs[0] != &c.Records[0] || recs[1] != &c.Records[1] || recs[2] != &c.Records[1] {
+		t.Error("records must be in classifier order and point to Records slice")
+	}
+	if recs = idx.Get("92432"); len(recs) != 1 || recs[0].ToSfrCode != "210" {
+		t.Errorf("unexpected records for '92432': %v", recs)
+	}
+	if recs = idx.Get("77000"); recs != nil {
+		t.Errorf("unexpected records for '77000': %v", recs)
+	}
+
+	n := 0
+	for range idx.All() {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("unexpected number of iterated keys: %d, expected 2", n)
+	}
+}
@@ -0,0 +1,63 @@
+package okato
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CheckDigitError - ошибка несовпадения контрольного числа кода ОКАТО.
+type CheckDigitError struct {
+	Code  string // Код ОКАТО
+	Check string // Указанное контрольное число
+	Want  int    // Рассчитанное контрольное число
+}
+
+func (e *CheckDigitError) Error() string {
+	return fmt.Sprintf("OKATO code '%s': check digit '%s' does not match computed %d", e.Code, e.Check, e.Want)
+}
+
+// CheckDigit - рассчитывает контрольное число кода ОКАТО.
+// Код может быть указан с точками ("01.201.800"), с пробелами ("01 201 800"),
+// без разделителей ("01201800") или в полном 11-символьном формате ("01201800000").
+// Возвращает ошибку, если код некорректен.
+func CheckDigit(code string) (int, error) {
+	c, err := Parse(code)
+	if err != nil {
+		return 0, err
+	}
+	if !c.standard() {
+		return 0, fmt.Errorf("invalid OKATO code '%s'", code)
+	}
+	digits := string(c)
+	if sum := weightedSum(digits, 1) % 11; sum < 10 {
+		return sum, nil
+	}
+	if sum := weightedSum(digits, 3) % 11; sum < 10 {
+		return sum, nil
+	}
+	return 0, nil
+}
+
+// Verify - проверяет, что check является контрольным числом кода ОКАТО code (см. CheckDigit).
+// Возвращает *CheckDigitError, если контрольное число не совпадает с рассчитанным.
+func Verify(code, check string) error {
+	want, err := CheckDigit(code)
+	if err != nil {
+		return err
+	}
+	if got, err := strconv.Atoi(strings.TrimSpace(check)); err != nil || got != want {
+		return &CheckDigitError{Code: code, Check: check, Want: want}
+	}
+	return nil
+}
+
+// weightedSum - возвращает сумму разрядов digits, умноженных на веса от start до 10, затем от 1 и т.д.
+func weightedSum(digits string, start int) int {
+	sum := 0
+	for i, ch := range digits {
+		weight := (start+i-1)%10 + 1
+		sum += int(ch-'0') * weight
+	}
+	return sum
+}
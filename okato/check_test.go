@@ -0,0 +1,70 @@
+package okato
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		code string
+		want int
+	}{
+		// Контрольные числа из выгрузки ЕСНСИ
+		{"01", 2},
+		{"01.200", 8},
+		{"01.201.800", 6},
+		{"01.201.802.002", 2},
+		// Разные форматы одного кода
+		{"01201800", 6},
+		{"01201800000", 6},
+		{"01 201 800", 6},
+		// Остаток 10 при весах 1, 2, ...: пересчет с весами 3, 4, ...
+		{"00.002", 3},
+		// Остаток 10 при обоих наборах весов
+		{"00.281", 0},
+	}
+	for _, tt := range tests {
+		got, err := CheckDigit(tt.code)
+		if err != nil {
+			t.Errorf("CheckDigit(%q): unexpected error: %v", tt.code, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CheckDigit(%q) = %d, expected %d", tt.code, got, tt.want)
+		}
+	}
+
+	for _, code := range []string{"", "1", "012", "01.20", "0120180000", "012018000001", "01.2O1", ";;classifierOkato_75.249.550"} {
+		if _, err := CheckDigit(code); err == nil {
+			t.Errorf("CheckDigit(%q): expected error", code)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if err := Verify("01.201.800", "6"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Verify("01201800", " 6 "); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, check := range []string{"5", "", "x"} {
+		err := Verify("01.201.800", check)
+		var ce *CheckDigitError
+		if !errors.As(err, &ce) {
+			t.Errorf("Verify with check %q: expected *CheckDigitError, got %v", check, err)
+			continue
+		}
+		if ce.Code != "01.201.800" || ce.Check != check || ce.Want != 6 {
+			t.Errorf("unexpected error fields: %+v", ce)
+		}
+	}
+
+	err := Verify("01.2", "6")
+	var ce *CheckDigitError
+	if err == nil || errors.As(err, &ce) {
+		t.Errorf("expected invalid code error, got %v", err)
+	}
+}
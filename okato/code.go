@@ -0,0 +1,186 @@
+package okato
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Code - код ОКАТО.
+//
+// Код хранится в сокращенной форме без разделителей: регион (2 символа) и группы по 3 символа,
+// без завершающих нулевых групп. Например, "01.201.800", "01 201 800", "01201800"
+// и "01201800000" - один и тот же код "01201800".
+//
+// Пустая строка - нулевое значение (код не задан).
+type Code string
+
+// reDotted - код ОКАТО с разделителями групп (точками или пробелами), например "01.201.800".
+var reDotted = regexp.MustCompile(`^\d{2}([. ]\d{3}){0,3}$`)
+
+// rePlain - код ОКАТО без разделителей, например "01201800".
+// Допускаются коды произвольной длины от 2 до 11 символов, как в справочнике SFR_CO.
+var rePlain = regexp.MustCompile(`^\d{2,11}$`)
+
+// Parse - разбирает код ОКАТО в формате с точками ("01.201.800"), с пробелами ("01 201 800"),
+// без разделителей ("01201800") или в полном 11-символьном формате ("01201800000").
+// Пробелы в начале и в конце строки игнорируются.
+func Parse(s string) (Code, error) {
+	code := strings.TrimSpace(s)
+	switch {
+	case rePlain.MatchString(code):
+	case reDotted.MatchString(code):
+		code = strings.NewReplacer(".", "", " ", "").Replace(code)
+	default:
+		return "", fmt.Errorf("invalid OKATO code '%s'", s)
+	}
+	// Отбрасываем завершающие нулевые группы
+	for (len(code) == 5 || len(code) == 8 || len(code) == 11) && strings.HasSuffix(code, "000") {
+		code = code[:len(code)-3]
+	}
+	return Code(code), nil
+}
+
+// MustParse - разбирает код ОКАТО (см. Parse). Вызывает панику, если код некорректен.
+func MustParse(s string) Code {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String - возвращает код в сокращенной форме без разделителей, например "01201800".
+func (c Code) String() string {
+	return string(c)
+}
+
+// Dotted - возвращает код с точками между группами, например "01.201.800".
+func (c Code) Dotted() string {
+	if len(c) <= 2 {
+		return string(c)
+	}
+	var b strings.Builder
+	b.WriteString(string(c[:2]))
+	for i := 2; i < len(c); i += 3 {
+		b.WriteByte('.')
+		b.WriteString(string(c[i:min(i+3, len(c))]))
+	}
+	return b.String()
+}
+
+// Full11 - возвращает полный 11-символьный код, дополненный нулями, например "01201800000".
+// Для пустого кода возвращает пустую строку.
+func (c Code) Full11() string {
+	if c == "" {
+		return ""
+	}
+	return string(c) + strings.Repeat("0", 11-len(c))
+}
+
+// Level - возвращает уровень кода:
+// 0 - регион ("01"), 1 - район или город ("01201"), 2 - сельсовет или поселок ("01201802"),
+// 3 - населенный пункт ("01201802002").
+func (c Code) Level() int {
+	return len(c) / 3
+}
+
+// Parent - возвращает код верхнего уровня, например "01201" для "01201802".
+// Для региона и пустого кода возвращает пустой код.
+func (c Code) Parent() Code {
+	if level := c.Level(); level > 0 {
+		return c[:2+3*(level-1)]
+	}
+	return ""
+}
+
+// HasPrefix - проверяет, что код входит в код prefix или совпадает с ним,
+// например код "01201802" входит в "01201" и "01".
+func (c Code) HasPrefix(prefix Code) bool {
+	return strings.HasPrefix(string(c), string(prefix))
+}
+
+// Compare - сравнивает коды в порядке полных 11-символьных кодов, при равенстве - по длине.
+// Возвращает -1, 0 или 1. Код верхнего уровня предшествует вложенным кодам.
+// Пример сортировки: slices.SortFunc(codes, okato.Code.Compare).
+func (c Code) Compare(other Code) int {
+	if r := strings.Compare(c.Full11(), other.Full11()); r != 0 {
+		return r
+	}
+	return cmp.Compare(len(c), len(other))
+}
+
+// standard - проверяет, что код состоит из региона и целых групп по 3 символа.
+func (c Code) standard() bool {
+	switch len(c) {
+	case 2, 5, 8, 11:
+		return true
+	}
+	return false
+}
+
+// MarshalText - реализует encoding.TextMarshaler.
+func (c Code) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+// UnmarshalText - реализует encoding.TextUnmarshaler. Пустой текст соответствует пустому коду.
+func (c *Code) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = ""
+		return nil
+	}
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// MarshalJSON - реализует json.Marshaler. Пустой код представляется как null.
+func (c Code) MarshalJSON() ([]byte, error) {
+	if c == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(c))
+}
+
+// UnmarshalJSON - реализует json.Unmarshaler.
+func (c *Code) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = ""
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal OKATO code: %w", err)
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+// Scan - реализует sql.Scanner. NULL соответствует пустому коду.
+func (c *Code) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = ""
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		return c.UnmarshalText(v)
+	default:
+		return fmt.Errorf("unsupported type %T for OKATO code", src)
+	}
+}
+
+// Value - реализует driver.Valuer. Пустой код сохраняется как NULL.
+func (c Code) Value() (driver.Value, error) {
+	if c == "" {
+		return nil, nil
+	}
+	return string(c), nil
+}
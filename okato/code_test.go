@@ -0,0 +1,209 @@
+package okato
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// Проверка реализации интерфейсов
+var (
+	_ encoding.TextMarshaler   = Code("")
+	_ encoding.TextUnmarshaler = (*Code)(nil)
+	_ json.Marshaler           = Code("")
+	_ json.Unmarshaler         = (*Code)(nil)
+	_ sql.Scanner              = (*Code)(nil)
+	_ driver.Valuer            = Code("")
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Code
+	}{
+		{"01", "01"},
+		{"01.201.800", "01201800"},
+		{"01 201 800", "01201800"},
+		{"01201800", "01201800"},
+		{"01201800000", "01201800"},
+		{" 01201800000 ", "01201800"},
+		{"01.201.802.002", "01201802002"},
+		{"01000000000", "01"},
+		{"01.200", "01200"},
+		{"92430000000", "92430"},
+		// Коды произвольной длины, как в справочнике SFR_CO
+		{"123456", "123456"},
+		{"1234000", "1234000"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", tt.s, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %q, expected %q", tt.s, got, tt.want)
+		}
+	}
+
+	for _, s := range []string{"", "1", "012018000001", "01.2018", "01..201", "01.201.", "01.201 800x", "O1", ";;classifierOkato_75.249.550"} {
+		if c, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q): expected error, got %q", s, c)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if c := MustParse("01.201"); c != "01201" {
+		t.Errorf("unexpected code: %q", c)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	MustParse("x")
+}
+
+func TestCode_Forms(t *testing.T) {
+	tests := []struct {
+		c      Code
+		dotted string
+		full11 string
+		level  int
+		parent Code
+	}{
+		{"01", "01", "01000000000", 0, ""},
+		{"01201", "01.201", "01201000000", 1, "01"},
+		{"01201800", "01.201.800", "01201800000", 2, "01201"},
+		{"01201802002", "01.201.802.002", "01201802002", 3, "01201802"},
+		{"123456", "12.345.6", "12345600000", 2, "12345"},
+		{"", "", "", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != string(tt.c) {
+			t.Errorf("%q: String() = %q", tt.c, got)
+		}
+		if got := tt.c.Dotted(); got != tt.dotted {
+			t.Errorf("%q: Dotted() = %q, expected %q", tt.c, got, tt.dotted)
+		}
+		if got := tt.c.Full11(); got != tt.full11 {
+			t.Errorf("%q: Full11() = %q, expected %q", tt.c, got, tt.full11)
+		}
+		if got := tt.c.Level(); got != tt.level {
+			t.Errorf("%q: Level() = %d, expected %d", tt.c, got, tt.level)
+		}
+		if got := tt.c.Parent(); got != tt.parent {
+			t.Errorf("%q: Parent() = %q, expected %q", tt.c, got, tt.parent)
+		}
+	}
+}
+
+func TestCode_HasPrefix(t *testing.T) {
+	c := Code("01201802")
+	for _, prefix := range []Code{"01", "01201", "01201802", ""} {
+		if !c.HasPrefix(prefix) {
+			t.Errorf("%q: expected prefix %q", c, prefix)
+		}
+	}
+	for _, prefix := range []Code{"02", "01202", "01201802002"} {
+		if c.HasPrefix(prefix) {
+			t.Errorf("%q: unexpected prefix %q", c, prefix)
+		}
+	}
+}
+
+func TestCode_Compare(t *testing.T) {
+	codes := []Code{"01201802", "02", "01201", "01", "01200", "01201802002", "012018"}
+	slices.SortFunc(codes, Code.Compare)
+	want := []Code{"01", "01200", "01201", "012018", "01201802", "01201802002", "02"}
+	if !slices.Equal(codes, want) {
+		t.Errorf("unexpected order: %v, expected %v", codes, want)
+	}
+	if r := Code("01").Compare("01"); r != 0 {
+		t.Errorf("unexpected compare result: %d", r)
+	}
+	if r := Code("02").Compare("01999"); r != 1 {
+		t.Errorf("unexpected compare result: %d", r)
+	}
+}
+
+func TestCode_Text(t *testing.T) {
+	text, err := Code("01201800").MarshalText()
+	if err != nil || string(text) != "01201800" {
+		t.Errorf("unexpected MarshalText result: %q, %v", text, err)
+	}
+
+	var c Code
+	if err = c.UnmarshalText([]byte("01.201.800")); err != nil || c != "01201800" {
+		t.Errorf("unexpected UnmarshalText result: %q, %v", c, err)
+	}
+	if err = c.UnmarshalText(nil); err != nil || c != "" {
+		t.Errorf("unexpected UnmarshalText result for empty text: %q, %v", c, err)
+	}
+	if err = c.UnmarshalText([]byte("x")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCode_JSON(t *testing.T) {
+	type record struct {
+		Code  Code  `json:"code"`
+		Empty Code  `json:"empty"`
+		Ptr   *Code `json:"ptr"`
+	}
+	data, err := json.Marshal(record{Code: "01201800"})
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if s := string(data); s != `{"code":"01201800","empty":null,"ptr":null}` {
+		t.Errorf("unexpected JSON: %s", s)
+	}
+
+	var rec record
+	if err = json.Unmarshal([]byte(`{"code":"01.201.800","empty":null,"ptr":"01000000000"}`), &rec); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if rec.Code != "01201800" || rec.Empty != "" || rec.Ptr == nil || *rec.Ptr != "01" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+
+	for _, s := range []string{`{"code":"x"}`, `{"code":1}`} {
+		if err = json.Unmarshal([]byte(s), &rec); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
+
+func TestCode_SQL(t *testing.T) {
+	v, err := Code("01201800").Value()
+	if err != nil || v != "01201800" {
+		t.Errorf("unexpected Value result: %v, %v", v, err)
+	}
+	if v, err = Code("").Value(); err != nil || v != nil {
+		t.Errorf("unexpected Value result for empty code: %v, %v", v, err)
+	}
+
+	var c Code
+	tests := []struct {
+		src  any
+		want Code
+	}{
+		{"01.201.800", "01201800"},
+		{[]byte("01201800000"), "01201800"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if err = c.Scan(tt.src); err != nil || c != tt.want {
+			t.Errorf("Scan(%v) = %q, %v, expected %q", tt.src, c, err, tt.want)
+		}
+	}
+	for _, src := range []any{"x", 1} {
+		if err = c.Scan(src); err == nil {
+			t.Errorf("Scan(%v): expected error", src)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Package okato предоставляет функции для работы с кодами Общероссийского классификатора объектов
+административно-территориального деления (ОКАТО).
+
+# Код ОКАТО
+
+Тип Code хранит код ОКАТО в сокращенной форме без разделителей и завершающих нулевых групп.
+Parse принимает код с точками, с пробелами, без разделителей или в полном 11-символьном формате:
+
+	code, err := okato.Parse("01.201.800")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(code)          // 01201800
+	fmt.Println(code.Full11()) // 01201800000
+	fmt.Println(code.Parent()) // 01201
+
+Code реализует encoding.TextMarshaler, json.Marshaler, sql.Scanner и driver.Valuer
+и может использоваться в структурах для JSON и в запросах к базе данных.
+
+# Контрольное число
+
+Контрольное число (КЧ) кода ОКАТО рассчитывается по методике, единой для общероссийских классификаторов:
+разряды кода умножаются на веса 1, 2, ..., 10, 1, 2, ..., и сумма произведений делится на 11.
+Остаток от деления является контрольным числом. Если остаток равен 10, расчет повторяется с весами 3, 4, ..., 10, 1, 2, ...,
+а если и он равен 10, контрольное число принимается равным 0.
+
+	check, err := okato.CheckDigit("01.201.800") // 6
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Проверка кода, введенного пользователем, вместе с контрольным числом
+	if err = okato.Verify("01201800", "6"); err != nil {
+		fmt.Println(err)
+	}
+*/
+package okato
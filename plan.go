@@ -0,0 +1,215 @@
+package esnsi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// planKey - ключ кэша планов декодирования.
+type planKey struct {
+	typ    reflect.Type // Тип записи
+	match  MatchMode    // Способ сопоставления тегов по умолчанию
+	schema uint64       // Отпечаток схемы классификатора
+}
+
+// plans - кэш скомпилированных планов декодирования (planKey -> *decodePlan).
+var plans sync.Map
+
+// decodePlan - скомпилированный план декодирования записей
+// для пары "тип записи - схема классификатора".
+type decodePlan struct {
+	fields      map[string]planField    // Поля записи по идентификатору атрибута
+	dynamic     map[string]dynamicField // Атрибуты по идентификатору для записей DynamicRecord
+	uidIndex    int                     // Индекс поля для идентификатора записи (-1, если поле не задано)
+	actionIndex int                     // Индекс поля для действия над записью (-1, если поле не задано)
+}
+
+// planField - поле записи, заполняемое значением атрибута.
+type planField struct {
+	index int        // Индекс поля в структуре записи
+	set   attrSetter // Функция установки значения
+}
+
+// attrSetter - функция установки значения атрибута в поле записи.
+type attrSetter func(field reflect.Value, attrVal *cnsiAttrVal) error
+
+// loadPlan - возвращает план декодирования для типа записи typ и схемы классификатора meta.
+// Скомпилированные планы кэшируются.
+func loadPlan(typ reflect.Type, match MatchMode, meta *cnsiMeta) (*decodePlan, error) {
+	key := planKey{typ: typ, match: match, schema: meta.fingerprint()}
+	if p, ok := plans.Load(key); ok {
+		return p.(*decodePlan), nil
+	}
+	p, err := compilePlan(typ, match, meta)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := plans.LoadOrStore(key, p)
+	return actual.(*decodePlan), nil
+}
+
+// compilePlan - компилирует план декодирования для типа записи typ и схемы классификатора meta.
+func compilePlan(typ reflect.Type, match MatchMode, meta *cnsiMeta) (*decodePlan, error) {
+	if typ == dynamicRecordType {
+		return compileDynamicPlan(match, meta)
+	}
+
+	// Создаем индексы атрибутов
+	attrKeyToRef := map[MatchMode]map[string]string{
+		MatchByName:     make(map[string]string),
+		MatchByTechName: make(map[string]string),
+		MatchByUID:      make(map[string]string),
+	}
+	attrRefToKind := make(map[string]reflect.Kind)
+	addAttr := func(uid, name, techName string, kind reflect.Kind) {
+		attrKeyToRef[MatchByName][name] = uid
+		if techName != "" {
+			attrKeyToRef[MatchByTechName][techName] = uid
+		}
+		attrKeyToRef[MatchByUID][uid] = uid
+		attrRefToKind[uid] = kind
+	}
+
+	// Атрибуты других типов (boolean, date, decimal, reference) не поддерживаются
+	for _, attr := range meta.Attrs {
+		switch attr.attrType() {
+		case AttrString, AttrText:
+			addAttr(attr.UID, attr.Name, attr.TechName, reflect.String)
+		case AttrInteger:
+			addAttr(attr.UID, attr.Name, attr.TechName, reflect.Int)
+		}
+	}
+
+	p := &decodePlan{
+		fields:      make(map[string]planField),
+		uidIndex:    -1,
+		actionIndex: -1,
+	}
+
+	// Проходим по всем полям структуры записи
+	// и проверяем, что для каждого поля с тегом esnsi
+	// существует соответствующий атрибут в классификаторе
+	// и что тип поля совпадает с типом атрибута
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Пропускаем поля без тега esnsi
+		attrName := field.Tag.Get("esnsi")
+		if attrName == "" {
+			continue
+		}
+
+		tag, err := parseTag(attrName, match)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		// Поля для идентификатора записи и действия над записью должны быть строковыми
+		if tag.Opt != "" {
+			if field.Type.Kind() != reflect.String {
+				return nil, fmt.Errorf("field %s has type %s, expected string for tag option %s",
+					field.Name, field.Type.Kind(), tag.Opt)
+			}
+			switch tag.Opt {
+			case tagOptUID:
+				p.uidIndex = i
+			case tagOptAction:
+				p.actionIndex = i
+			}
+			continue
+		}
+
+		// Проверяем, что атрибут существует в классификаторе
+		refs, ok := attrKeyToRef[tag.Mode]
+		if !ok {
+			return nil, fmt.Errorf("unsupported match mode %s for field %s", tag.Mode, field.Name)
+		}
+		uid, ok := refs[tag.Key]
+		if !ok {
+			// Необязательный атрибут может отсутствовать, поле остается пустым
+			if tag.Optional {
+				continue
+			}
+			return nil, fmt.Errorf("attribute %s not found in classifier", attrName)
+		}
+		kind, ok := attrRefToKind[uid]
+		if !ok {
+			return nil, fmt.Errorf("attribute %s with Ref %s not found in classifier", attrName, uid)
+		}
+
+		// Проверяем, что тип поля совпадает с типом атрибута
+		if field.Type.Kind() != kind {
+			return nil, fmt.Errorf("field %s has type %s, expected %s for attribute %s with Ref %s",
+				field.Name, field.Type.Kind(), kind, attrName, uid)
+		}
+
+		// Выбираем функцию установки значения
+		var set attrSetter
+		switch kind {
+		case reflect.String:
+			set = setStringAttr
+		case reflect.Int:
+			set = setIntAttr
+		default:
+			return nil, fmt.Errorf("unsupported field type: %s", kind)
+		}
+		p.fields[uid] = planField{index: i, set: set}
+	}
+
+	return p, nil
+}
+
+// fill - заполняет запись val значениями из записи документа.
+func (p *decodePlan) fill(val reflect.Value, docRecord *cnsiRecord) error {
+	if p.dynamic != nil {
+		return p.fillDynamic(val.Addr().Interface().(*DynamicRecord), docRecord)
+	}
+
+	// Заполняем идентификатор записи и действие над записью
+	if p.uidIndex >= 0 {
+		val.Field(p.uidIndex).SetString(docRecord.UID)
+	}
+	if p.actionIndex >= 0 {
+		action := docRecord.Action
+		if action == "" {
+			action = cnsiActionDefault
+		}
+		val.Field(p.actionIndex).SetString(action)
+	}
+
+	// Заполняем поля структуры записи
+	for i := range docRecord.AttrVals {
+		attrVal := &docRecord.AttrVals[i]
+		f, found := p.fields[attrVal.AttrRef]
+		if !found {
+			continue // Поле не нужно сохранять
+		}
+		if err := f.set(val.Field(f.index), attrVal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStringAttr - устанавливает значение строкового или текстового атрибута.
+func setStringAttr(field reflect.Value, attrVal *cnsiAttrVal) error {
+	switch {
+	case attrVal.StringVal != nil:
+		field.SetString(attrVal.StringVal.Val)
+	case attrVal.TextVal != nil:
+		field.SetString(attrVal.TextVal.Val)
+	default:
+		return fmt.Errorf("string value for attribute %s is not set", attrVal.AttrRef)
+	}
+	return nil
+}
+
+// setIntAttr - устанавливает значение целочисленного атрибута.
+func setIntAttr(field reflect.Value, attrVal *cnsiAttrVal) error {
+	if attrVal.IntegerVal == nil {
+		return fmt.Errorf("integer value for attribute %s is not set", attrVal.AttrRef)
+	}
+	field.SetInt(int64(attrVal.IntegerVal.Val))
+	return nil
+}
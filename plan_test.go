@@ -0,0 +1,185 @@
+package esnsi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//goland:noinspection GoUnhandledErrorResult
+func TestLoadPlan(t *testing.T) {
+	doc := readTestDoc(t, "testdata/decoder-valid_test.xml")
+	typ := reflect.TypeOf(testRecord{})
+
+	t.Run("cached", func(t *testing.T) {
+		p1, err := loadPlan(typ, MatchByName, &doc.Meta)
+		if err != nil {
+			t.Fatalf("failed to load plan: %v", err)
+		}
+		p2, err := loadPlan(typ, MatchByName, &doc.Meta)
+		if err != nil {
+			t.Fatalf("failed to load plan: %v", err)
+		}
+		if p1 != p2 {
+			t.Error("expected cached plan to be reused")
+		}
+		if len(p1.fields) != 3 {
+			t.Errorf("unexpected number of plan fields: %d, expected 3", len(p1.fields))
+		}
+	})
+
+	t.Run("schema changed", func(t *testing.T) {
+		p1, err := loadPlan(typ, MatchByName, &doc.Meta)
+		if err != nil {
+			t.Fatalf("failed to load plan: %v", err)
+		}
+
+		// Переименовываем атрибут, не участвующий в сопоставлении
+		meta := doc.Meta
+		meta.Attrs = append([]cnsiAttr(nil), doc.Meta.Attrs...)
+		meta.Attrs[1].Name = "RegionCodeRenamed"
+		if meta.fingerprint() == doc.Meta.fingerprint() {
+			t.Fatal("expected fingerprint to change")
+		}
+
+		p2, err := loadPlan(typ, MatchByName, &meta)
+		if err != nil {
+			t.Fatalf("failed to load plan: %v", err)
+		}
+		if p1 == p2 {
+			t.Error("expected new plan for changed schema")
+		}
+	})
+
+	t.Run("optional attribute", func(t *testing.T) {
+		// Отсутствующий необязательный атрибут не попадает в план
+		p, err := loadPlan(reflect.TypeOf(testOptionalRecord{}), MatchByName, &doc.Meta)
+		if err != nil {
+			t.Fatalf("failed to load plan: %v", err)
+		}
+		if len(p.fields) != 2 {
+			t.Errorf("unexpected number of plan fields: %d, expected 2", len(p.fields))
+		}
+		for _, f := range p.fields {
+			if f.index == 2 {
+				t.Error("unexpected plan field for missing optional attribute")
+			}
+		}
+	})
+
+	t.Run("error not cached", func(t *testing.T) {
+		wrongTyp := reflect.TypeOf(testWrongFieldTypeRecord{})
+		if _, err := loadPlan(wrongTyp, MatchByName, &doc.Meta); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		key := planKey{typ: wrongTyp, match: MatchByName, schema: doc.Meta.fingerprint()}
+		if _, ok := plans.Load(key); ok {
+			t.Error("expected failed plan not to be cached")
+		}
+	})
+}
+
+// BenchmarkDecoder_Decode - декодирование классификатора с большим количеством записей.
+func BenchmarkDecoder_Decode(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		data := genTestXML(b, n)
+		b.Run(fmt.Sprintf("records=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				c := &Classifier[testRecord]{}
+				if err := NewDecoder[testRecord](bytes.NewReader(data)).Decode(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecoder_unmarshal - заполнение классификатора из разобранного документа
+// с кэшированным планом и с компиляцией плана при каждом вызове.
+func BenchmarkDecoder_unmarshal(b *testing.B) {
+	for _, n := range []int{4, 10000} {
+		doc := &cnsiDoc{}
+		if err := xml.Unmarshal(genTestXML(b, n), doc); err != nil {
+			b.Fatal(err)
+		}
+		typ := reflect.TypeOf(testRecord{})
+
+		b.Run(fmt.Sprintf("cached/records=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			d := NewDecoder[testRecord](nil)
+			for b.Loop() {
+				if err := d.unmarshal(&Classifier[testRecord]{}, doc); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("compile/records=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				plan, err := compilePlan(typ, MatchByName, &doc.Meta)
+				if err != nil {
+					b.Fatal(err)
+				}
+				records := make([]testRecord, len(doc.Records))
+				for i := range doc.Records {
+					if err := plan.fill(reflect.ValueOf(&records[i]).Elem(), &doc.Records[i]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// readTestDoc - читает и разбирает тестовый документ формата ЦНСИ.
+//
+//goland:noinspection GoUnhandledErrorResult
+func readTestDoc(tb testing.TB, name string) *cnsiDoc {
+	tb.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		tb.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	doc := &cnsiDoc{}
+	if err = xml.NewDecoder(f).Decode(doc); err != nil {
+		tb.Fatalf("failed to decode test file: %v", err)
+	}
+	return doc
+}
+
+// genTestXML - формирует документ формата ЦНСИ со схемой decoder-valid_test.xml и n записями.
+func genTestXML(tb testing.TB, n int) []byte {
+	tb.Helper()
+	src, err := os.ReadFile("testdata/decoder-valid_test.xml")
+	if err != nil {
+		tb.Fatalf("failed to read test file: %v", err)
+	}
+	s := string(src)
+	start := strings.Index(s, "<nsi:record ")
+	end := strings.Index(s, "</nsi:data>")
+	if start < 0 || end < 0 {
+		tb.Fatal("unexpected test file structure")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(s[:start])
+	for i := range n {
+		_, _ = fmt.Fprintf(&buf, `<nsi:record uid="rec-%d">
+            <nsi:attribute-value attribute-ref="cf6621f2-438d-4744-8158-63bb963bb11f"><nsi:string>%03d</nsi:string></nsi:attribute-value>
+            <nsi:attribute-value attribute-ref="fea2973c-541f-422d-9093-102c50c1f7cb"><nsi:string>%03d</nsi:string></nsi:attribute-value>
+            <nsi:attribute-value attribute-ref="94737d3c-3d52-47f5-bdd5-e4999fb9dbca"><nsi:string>Регион %d</nsi:string></nsi:attribute-value>
+            <nsi:attribute-value attribute-ref="bc947ef4-bc67-433a-99a3-10654ff62698"><nsi:integer>%d</nsi:integer></nsi:attribute-value>
+        </nsi:record>
+`, i, i%1000, i%100, i, i%5)
+	}
+	buf.WriteString(s[end:])
+	return buf.Bytes()
+}
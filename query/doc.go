@@ -0,0 +1,21 @@
+/*
+Package query предоставляет отбор, сортировку и постраничный вывод записей классификаторов ЕСНСИ.
+
+Условия строятся функциями Eq, In, Prefix, Contains, Range и объединяются And, Or, Not.
+Поля задаются атрибутами из тегов esnsi типа записи (или именами полей структуры):
+
+	res, err := query.Find(&sfr.Classifier, query.Query{
+		Where:   query.And(query.Eq("OfficeType", 2), query.Prefix("RegionName", "Респ")),
+		OrderBy: []query.Order{{Field: "ToSfrCode"}},
+		Limit:   10,
+	})
+
+Тот же запрос в текстовом синтаксисе (см. Parse) разбирается в то же синтаксическое дерево:
+
+	q, err := query.Parse(`OfficeType = 2 AND RegionName PREFIX "Респ" ORDER BY ToSfrCode LIMIT 10`)
+	res, err := query.Find(&sfr.Classifier, q)
+
+Ошибки разбора возвращаются как *SyntaxError с позицией в тексте запроса. Длина текста запроса
+ограничена MaxLength, вложенность скобок и операторов NOT - MaxDepth.
+*/
+package query
@@ -0,0 +1,263 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// Find - возвращает записи классификатора c, отобранные запросом q.
+// Поля запроса - атрибуты из тегов esnsi типа записи T или имена полей структуры.
+// Возвращает ошибку, если поле не найдено или тип значения не совпадает с типом поля.
+func Find[T any](c *esnsi.Classifier[T], q Query) ([]*T, error) {
+	if c == nil {
+		return nil, fmt.Errorf("nil pointer passed")
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return nil, fmt.Errorf("negative limit or offset")
+	}
+	fields, err := recordFields[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	match := func(*T) bool { return true }
+	if q.Where != nil {
+		if match, err = compile[T](q.Where, fields); err != nil {
+			return nil, err
+		}
+	}
+	var orders []func(a, b *T) int
+	for _, o := range q.OrderBy {
+		f, ok := fields[o.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown field '%s'", o.Field)
+		}
+		desc := o.Desc
+		orders = append(orders, func(a, b *T) int {
+			r := compare(f.value(a), f.value(b))
+			if desc {
+				return -r
+			}
+			return r
+		})
+	}
+
+	var res []*T
+	for i := range c.Records {
+		if match(&c.Records[i]) {
+			res = append(res, &c.Records[i])
+		}
+	}
+	if len(orders) > 0 {
+		slices.SortStableFunc(res, func(a, b *T) int {
+			for _, order := range orders {
+				if r := order(a, b); r != 0 {
+					return r
+				}
+			}
+			return 0
+		})
+	}
+
+	if q.Offset >= len(res) {
+		return nil, nil
+	}
+	res = res[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(res) {
+		res = res[:q.Limit]
+	}
+	return res, nil
+}
+
+// FindString - разбирает текст запроса (см. Parse) и возвращает отобранные записи классификатора c.
+func FindString[T any](c *esnsi.Classifier[T], s string) ([]*T, error) {
+	q, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return Find(c, q)
+}
+
+// field - поле записи, доступное в запросе.
+type field struct {
+	esnsi.Field
+	kind reflect.Kind // Тип поля: reflect.String или reflect.Int
+}
+
+// value - возвращает значение поля записи rec как string или int.
+// Поля именованных типов (type Name string) приводятся к базовому типу.
+func (f field) value(rec any) any {
+	v := reflect.ValueOf(rec).Elem().Field(f.Index)
+	switch f.kind {
+	case reflect.String:
+		return v.String()
+	case reflect.Int:
+		return int(v.Int())
+	default:
+		return v.Interface()
+	}
+}
+
+// recordFields - возвращает поля записи T по атрибутам и именам полей структуры.
+func recordFields[T any]() (map[string]field, error) {
+	list, err := esnsi.Fields[T]()
+	if err != nil {
+		return nil, err
+	}
+	typ := reflect.TypeOf(*new(T))
+	fields := make(map[string]field, len(list)*2)
+	for _, f := range list {
+		ff := field{Field: f, kind: typ.Field(f.Index).Type.Kind()}
+		if _, ok := fields[f.Name]; !ok {
+			fields[f.Name] = ff
+		}
+		fields[f.Attr] = ff
+	}
+	return fields, nil
+}
+
+// compile - преобразует условие в функцию проверки записи.
+func compile[T any](e Expr, fields map[string]field) (func(*T) bool, error) {
+	switch e := e.(type) {
+	case *AndExpr:
+		fns, err := compileAll[T](e.Exprs, fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(rec *T) bool {
+			for _, fn := range fns {
+				if !fn(rec) {
+					return false
+				}
+			}
+			return true
+		}, nil
+
+	case *OrExpr:
+		fns, err := compileAll[T](e.Exprs, fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(rec *T) bool {
+			for _, fn := range fns {
+				if fn(rec) {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	case *NotExpr:
+		fn, err := compile[T](e.Expr, fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(rec *T) bool { return !fn(rec) }, nil
+
+	case *Pred:
+		return compilePred[T](e, fields)
+
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", e)
+	}
+}
+
+// compileAll - преобразует условия в функции проверки записи.
+func compileAll[T any](exprs []Expr, fields map[string]field) ([]func(*T) bool, error) {
+	fns := make([]func(*T) bool, len(exprs))
+	for i, e := range exprs {
+		fn, err := compile[T](e, fields)
+		if err != nil {
+			return nil, err
+		}
+		fns[i] = fn
+	}
+	return fns, nil
+}
+
+// compilePred - преобразует условие на значение поля в функцию проверки записи.
+func compilePred[T any](p *Pred, fields map[string]field) (func(*T) bool, error) {
+	f, ok := fields[p.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field '%s'", p.Field)
+	}
+	for _, v := range p.Values {
+		if v == nil && p.Op == OpRange {
+			continue
+		}
+		if err := checkValue(f, v); err != nil {
+			return nil, err
+		}
+	}
+
+	switch p.Op {
+	case OpEq, OpIn:
+		if len(p.Values) == 0 || (p.Op == OpEq && len(p.Values) != 1) {
+			return nil, fmt.Errorf("%s: invalid number of values for %s", p.Field, p.Op)
+		}
+		return func(rec *T) bool {
+			return slices.Contains(p.Values, f.value(rec))
+		}, nil
+
+	case OpPrefix, OpContains:
+		if f.kind != reflect.String {
+			return nil, fmt.Errorf("%s: %s requires string field", p.Field, p.Op)
+		}
+		if len(p.Values) != 1 {
+			return nil, fmt.Errorf("%s: invalid number of values for %s", p.Field, p.Op)
+		}
+		s := p.Values[0].(string)
+		test := strings.HasPrefix
+		if p.Op == OpContains {
+			test = strings.Contains
+		}
+		return func(rec *T) bool {
+			return test(f.value(rec).(string), s)
+		}, nil
+
+	case OpRange:
+		if len(p.Values) != 2 {
+			return nil, fmt.Errorf("%s: invalid number of values for %s", p.Field, p.Op)
+		}
+		from, to := p.Values[0], p.Values[1]
+		return func(rec *T) bool {
+			v := f.value(rec)
+			return (from == nil || compare(v, from) >= 0) && (to == nil || compare(v, to) <= 0)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("%s: unsupported operator %s", p.Field, p.Op)
+	}
+}
+
+// checkValue - проверяет соответствие типа значения типу поля.
+func checkValue(f field, v any) error {
+	switch v.(type) {
+	case string:
+		if f.kind == reflect.String {
+			return nil
+		}
+	case int:
+		if f.kind == reflect.Int {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %s field, got %T value %v", f.Attr, f.kind, v, v)
+}
+
+// compare - сравнивает значения полей одного типа (string или int).
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case string:
+		return cmp.Compare(a, b.(string))
+	case int:
+		return cmp.Compare(a, b.(int))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+func TestFind(t *testing.T) {
+	c := loadTestClassifier(t)
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected []string // ToSfrCode
+	}{
+		{"all", Query{}, []string{"210", "201", "059", "041"}},
+		{"eq", Query{Where: Eq("RegionCode", "013")}, []string{"210"}},
+		{"go field name", Query{Where: Eq("Code", "201")}, []string{"201"}},
+		{"in", Query{Where: In("ToSfrCode", "041", "059", "999")}, []string{"059", "041"}},
+		{"prefix", Query{Where: Prefix("RegionName", "Мос")}, []string{"201"}},
+		{"contains", Query{Where: Contains("RegionName", "ская")}, []string{"059", "041"}},
+		{"range", Query{Where: Range("ToSfrCode", "050", "205")}, []string{"201", "059"}},
+		{"open range", Query{Where: Range("ToSfrCode", nil, "059")}, []string{"059", "041"}},
+		{"and not", Query{Where: And(Eq("OfficeType", 2), Not(Contains("RegionName", "область")))}, []string{"210", "201"}},
+		{"or", Query{Where: Or(Eq("ToSfrCode", "041"), Eq("ToSfrCode", "210"))}, []string{"210", "041"}},
+		{"order", Query{OrderBy: []Order{{Field: "ToSfrCode"}}}, []string{"041", "059", "201", "210"}},
+		{"order desc limit offset", Query{OrderBy: []Order{{Field: "RegionCode", Desc: true}}, Limit: 2, Offset: 1},
+			[]string{"059", "041"}},
+		{"offset beyond", Query{Offset: 10}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Find(c, tt.query)
+			if err != nil {
+				t.Fatalf("failed to find: %v", err)
+			}
+			var codes []string
+			for _, rec := range res {
+				codes = append(codes, rec.Code)
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("unexpected records: %v, expected %v", codes, tt.expected)
+			}
+		})
+	}
+
+	t.Run("text syntax", func(t *testing.T) {
+		res, err := FindString(c, `RegionCode = "013" OR OfficeType = 2 AND RegionName CONTAINS "Бел" ORDER BY ToSfrCode`)
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		if len(res) != 2 || res[0].Code != "041" || res[1].Code != "210" {
+			t.Errorf("unexpected records: %+v", res)
+		}
+		if res[0] != &c.Records[3] {
+			t.Error("expected pointers to classifier records")
+		}
+	})
+
+	t.Run("named types", func(t *testing.T) {
+		f, err := os.Open("../testdata/decoder-valid_test.xml")
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		named := &esnsi.Classifier[testNamedRecord]{}
+		if err = esnsi.NewDecoder[testNamedRecord](f).Decode(named); err != nil {
+			t.Fatalf("failed to decode classifier: %v", err)
+		}
+
+		for _, tt := range []struct {
+			query    string
+			expected []string
+		}{
+			{`RegionName PREFIX "Мос"`, []string{"201"}},
+			{`RegionName CONTAINS "ская"`, []string{"059", "041"}},
+			{`ToSfrCode = "059"`, []string{"059"}},
+			{`ToSfrCode IN ("041", "210")`, []string{"210", "041"}},
+			{`OfficeType = 2 AND ToSfrCode >= "200"`, []string{"210", "201"}},
+			{`ORDER BY ToSfrCode`, []string{"041", "059", "201", "210"}},
+			{`OfficeType BETWEEN 1 AND 2 ORDER BY OfficeType, ToSfrCode DESC`, []string{"210", "201", "059", "041"}},
+			{`OfficeType IN (1, 3)`, nil},
+		} {
+			res, err := FindString(named, tt.query)
+			if err != nil {
+				t.Fatalf("%s: failed to find: %v", tt.query, err)
+			}
+			var codes []string
+			for _, rec := range res {
+				codes = append(codes, string(rec.Code))
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("%s: unexpected records: %v, expected %v", tt.query, codes, tt.expected)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, q := range []Query{
+			{Where: Eq("Unknown", "1")},
+			{Where: Eq("OfficeType", "2")},
+			{Where: Prefix("OfficeType", "2")},
+			{Where: In("RegionCode")},
+			{OrderBy: []Order{{Field: "Unknown"}}},
+			{Limit: -1},
+		} {
+			if _, err := Find(c, q); err == nil {
+				t.Errorf("%s: expected error, got nil", q)
+			}
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	Code       string `esnsi:"ToSfrCode"`
+	RegionCode string `esnsi:"RegionCode"`
+	RegionName string `esnsi:"RegionName"`
+	OfficeType int    `esnsi:"OfficeType"`
+}
+
+// testNamedRecord - тестовая запись классификатора с полями именованных типов
+type testNamedRecord struct {
+	Code       testString `esnsi:"ToSfrCode"`
+	RegionName testString `esnsi:"RegionName"`
+	OfficeType testInt    `esnsi:"OfficeType"`
+}
+
+type (
+	testString string
+	testInt    int
+)
+
+// loadTestClassifier - загружает тестовый классификатор.
+//
+//goland:noinspection GoUnhandledErrorResult
+func loadTestClassifier(t *testing.T) *esnsi.Classifier[testRecord] {
+	t.Helper()
+	f, err := os.Open("../testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	c := &esnsi.Classifier[testRecord]{}
+	if err = esnsi.NewDecoder[testRecord](f).Decode(c); err != nil {
+		t.Fatalf("failed to decode classifier: %v", err)
+	}
+	return c
+}
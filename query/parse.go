@@ -0,0 +1,482 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError - ошибка разбора текста запроса.
+type SyntaxError struct {
+	Pos int    // Позиция в тексте запроса (в байтах, с 0)
+	Msg string // Описание ошибки
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+const (
+	MaxLength = 64 << 10 // Максимальная длина текста запроса в байтах
+	MaxDepth  = 100      // Максимальная вложенность скобок и операторов NOT
+)
+
+// Parse - разбирает текст запроса.
+//
+// Синтаксис:
+//
+//	[условие] [ORDER BY поле [ASC|DESC], ...] [LIMIT n] [OFFSET n]
+//
+// Условия на значения полей:
+//
+//	RegionCode = "013"                  Eq
+//	RegionCode != "013"                 Not(Eq)
+//	OfficeType IN (1, 2)                In
+//	RegionName PREFIX "Респ"            Prefix
+//	RegionName CONTAINS "обл"           Contains
+//	OfficeType BETWEEN 1 AND 3          Range
+//	OfficeType >= 1, OfficeType <= 3    Range с открытой границей
+//
+// Условия объединяются операторами AND, OR, NOT и скобками; AND связывает сильнее OR.
+// Строки заключаются в двойные кавычки, целые числа записываются без кавычек.
+// Ключевые слова не зависят от регистра.
+//
+// Длина текста запроса ограничена MaxLength, вложенность условий - MaxDepth.
+func Parse(s string) (Query, error) {
+	p, err := newParser(s)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	if p.tok.kind != tokEOF && !p.keyword("ORDER") && !p.keyword("LIMIT") && !p.keyword("OFFSET") {
+		where, err := p.parseOr()
+		if err != nil {
+			return Query{}, err
+		}
+		q.Where = where
+	}
+	if p.keyword("ORDER") {
+		p.next()
+		if err := p.expectKeyword("BY"); err != nil {
+			return Query{}, err
+		}
+		for {
+			field, err := p.expectIdent()
+			if err != nil {
+				return Query{}, err
+			}
+			o := Order{Field: field}
+			if p.keyword("DESC") {
+				o.Desc = true
+				p.next()
+			} else if p.keyword("ASC") {
+				p.next()
+			}
+			q.OrderBy = append(q.OrderBy, o)
+			if p.tok.kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.keyword("LIMIT") {
+		p.next()
+		n, err := p.expectCount()
+		if err != nil {
+			return Query{}, err
+		}
+		q.Limit = n
+	}
+	if p.keyword("OFFSET") {
+		p.next()
+		n, err := p.expectCount()
+		if err != nil {
+			return Query{}, err
+		}
+		q.Offset = n
+	}
+	if p.tok.kind != tokEOF || p.err != nil {
+		return Query{}, p.errorf("unexpected %s", p.tok)
+	}
+	return q, nil
+}
+
+// ParseExpr - разбирает текст условия отбора без сортировки и ограничений (см. Parse).
+func ParseExpr(s string) (Expr, error) {
+	p, err := newParser(s)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, p.errorf("empty expression")
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF || p.err != nil {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return e, nil
+}
+
+// parser - синтаксический анализатор запроса.
+type parser struct {
+	lex   lexer
+	tok   token // Текущая лексема
+	err   error // Ошибка лексического анализа
+	depth int   // Текущая вложенность условий
+}
+
+// newParser - создает синтаксический анализатор текста запроса s и читает первую лексему.
+func newParser(s string) (*parser, error) {
+	if len(s) > MaxLength {
+		return nil, &SyntaxError{Pos: MaxLength, Msg: fmt.Sprintf("query exceeds %d bytes", MaxLength)}
+	}
+	p := &parser{lex: lexer{src: s}}
+	p.next()
+	return p, nil
+}
+
+// next - переходит к следующей лексеме.
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.next()
+	if p.err != nil {
+		p.tok = token{kind: tokEOF, pos: p.tok.pos}
+	}
+}
+
+// keyword - проверяет, что текущая лексема - ключевое слово kw.
+func (p *parser) keyword(kw string) bool {
+	return p.tok.kind == tokIdent && strings.EqualFold(p.tok.text, kw)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.keyword(kw) {
+		return p.errorf("expected %s, got %s", kw, p.tok)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectIdent() (string, error) {
+	if p.tok.kind != tokIdent || isKeyword(p.tok.text) {
+		return "", p.errorf("expected field name, got %s", p.tok)
+	}
+	s := p.tok.text
+	p.next()
+	return s, nil
+}
+
+func (p *parser) expectCount() (int, error) {
+	if p.tok.kind != tokNumber || p.tok.value.(int) < 0 {
+		return 0, p.errorf("expected non-negative number, got %s", p.tok)
+	}
+	n := p.tok.value.(int)
+	p.next()
+	return n, nil
+}
+
+func (p *parser) expectValue() (any, error) {
+	if p.tok.kind != tokString && p.tok.kind != tokNumber {
+		return nil, p.errorf("expected string or number, got %s", p.tok)
+	}
+	v := p.tok.value
+	p.next()
+	return v, nil
+}
+
+// parseOr - условие := and { OR and }
+func (p *parser) parseOr() (Expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{e}
+	for p.keyword("OR") {
+		p.next()
+		if e, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return Or(exprs...), nil
+}
+
+// parseAnd - and := unary { AND unary }
+func (p *parser) parseAnd() (Expr, error) {
+	e, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{e}
+	for p.keyword("AND") {
+		p.next()
+		if e, err = p.parseUnary(); err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return And(exprs...), nil
+}
+
+// parseUnary - unary := NOT unary | "(" условие ")" | pred
+func (p *parser) parseUnary() (Expr, error) {
+	if p.keyword("NOT") || p.tok.kind == tokLParen {
+		if p.depth >= MaxDepth {
+			return nil, p.errorf("nesting exceeds %d levels", MaxDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	switch {
+	case p.keyword("NOT"):
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(e), nil
+	case p.tok.kind == tokLParen:
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ')', got %s", p.tok)
+		}
+		p.next()
+		return e, nil
+	default:
+		return p.parsePred()
+	}
+}
+
+// parsePred - pred := поле оператор значение
+func (p *parser) parsePred() (Expr, error) {
+	field, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.tok.kind == tokOp:
+		op := p.tok.text
+		p.next()
+		v, err := p.expectValue()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "=":
+			return Eq(field, v), nil
+		case "!=":
+			return Not(Eq(field, v)), nil
+		case ">=":
+			return Range(field, v, nil), nil
+		default: // "<="
+			return Range(field, nil, v), nil
+		}
+
+	case p.keyword("IN"):
+		p.next()
+		if p.tok.kind != tokLParen {
+			return nil, p.errorf("expected '(', got %s", p.tok)
+		}
+		p.next()
+		var values []any
+		for {
+			v, err := p.expectValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+			if p.tok.kind != tokComma {
+				break
+			}
+			p.next()
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ')', got %s", p.tok)
+		}
+		p.next()
+		return In(field, values...), nil
+
+	case p.keyword("PREFIX"), p.keyword("CONTAINS"):
+		contains := p.keyword("CONTAINS")
+		p.next()
+		if p.tok.kind != tokString {
+			return nil, p.errorf("expected string, got %s", p.tok)
+		}
+		s := p.tok.value.(string)
+		p.next()
+		if contains {
+			return Contains(field, s), nil
+		}
+		return Prefix(field, s), nil
+
+	case p.keyword("BETWEEN"):
+		p.next()
+		from, err := p.expectValue()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		to, err := p.expectValue()
+		if err != nil {
+			return nil, err
+		}
+		return Range(field, from, to), nil
+	}
+
+	return nil, p.errorf("expected operator after %s, got %s", field, p.tok)
+}
+
+// keywords - ключевые слова синтаксиса запроса
+var keywords = []string{"AND", "OR", "NOT", "IN", "PREFIX", "CONTAINS", "BETWEEN", "ORDER", "BY", "ASC", "DESC", "LIMIT", "OFFSET"}
+
+// isKeyword - проверяет, что s - ключевое слово.
+func isKeyword(s string) bool {
+	for _, kw := range keywords {
+		if strings.EqualFold(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenKind - вид лексемы.
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota // Конец текста
+	tokIdent                   // Имя поля или ключевое слово
+	tokString                  // Строка в кавычках
+	tokNumber                  // Целое число
+	tokOp                      // Оператор сравнения: = != >= <=
+	tokLParen                  // (
+	tokRParen                  // )
+	tokComma                   // ,
+)
+
+// token - лексема.
+type token struct {
+	kind  tokenKind
+	pos   int    // Позиция в тексте
+	text  string // Текст лексемы
+	value any    // Значение строки или числа
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of query"
+	}
+	return "'" + t.text + "'"
+}
+
+// lexer - лексический анализатор запроса.
+type lexer struct {
+	src string
+	pos int
+}
+
+// next - возвращает следующую лексему.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	switch {
+	case r == '(' || r == ')' || r == ',':
+		l.pos++
+		kind := map[rune]tokenKind{'(': tokLParen, ')': tokRParen, ',': tokComma}[r]
+		return token{kind: kind, pos: start, text: string(r)}, nil
+
+	case r == '=':
+		l.pos++
+		return token{kind: tokOp, pos: start, text: "="}, nil
+
+	case r == '!' || r == '>' || r == '<':
+		if !strings.HasPrefix(l.src[l.pos+1:], "=") {
+			return token{pos: start}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unsupported operator '%c'", r)}
+		}
+		l.pos += 2
+		return token{kind: tokOp, pos: start, text: l.src[start:l.pos]}, nil
+
+	case r == '"':
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != '"' {
+			if l.src[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		if l.pos >= len(l.src) {
+			return token{pos: start}, &SyntaxError{Pos: start, Msg: "unterminated string"}
+		}
+		l.pos++
+		text := l.src[start:l.pos]
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return token{pos: start}, &SyntaxError{Pos: start, Msg: "invalid string " + text}
+		}
+		return token{kind: tokString, pos: start, text: text, value: s}, nil
+
+	case r == '-' || unicode.IsDigit(r):
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] >= '0' && l.src[l.pos] <= '9' {
+			l.pos++
+		}
+		text := l.src[start:l.pos]
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return token{pos: start}, &SyntaxError{Pos: start, Msg: "invalid number " + text}
+		}
+		return token{kind: tokNumber, pos: start, text: text, value: n}, nil
+
+	case r == '_' || unicode.IsLetter(r):
+		l.pos += size
+		for l.pos < len(l.src) {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			l.pos += size
+		}
+		return token{kind: tokIdent, pos: start, text: l.src[start:l.pos]}, nil
+	}
+
+	return token{pos: start}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected character '%c'", r)}
+}
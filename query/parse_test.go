@@ -0,0 +1,127 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected Query
+	}{
+		{"empty", "", Query{}},
+		{"eq", `RegionCode = "013"`, Query{Where: Eq("RegionCode", "013")}},
+		{"and or precedence", `RegionCode = "013" OR OfficeType = 2 and RegionName prefix "Мос"`,
+			Query{Where: Or(Eq("RegionCode", "013"), And(Eq("OfficeType", 2), Prefix("RegionName", "Мос")))}},
+		{"parens and not", `NOT (OfficeType IN (1, 2) OR RegionName CONTAINS "обл")`,
+			Query{Where: Not(Or(In("OfficeType", 1, 2), Contains("RegionName", "обл")))}},
+		{"not equal", `RegionCode != "013"`, Query{Where: Not(Eq("RegionCode", "013"))}},
+		{"range", `OfficeType BETWEEN 1 AND 3 AND RegionCode >= "050" AND OfficeType <= -1`,
+			Query{Where: And(Range("OfficeType", 1, 3), Range("RegionCode", "050", nil), Range("OfficeType", nil, -1))}},
+		{"order limit offset", `ORDER BY RegionCode DESC, RegionName asc LIMIT 10 OFFSET 20`,
+			Query{OrderBy: []Order{{Field: "RegionCode", Desc: true}, {Field: "RegionName"}}, Limit: 10, Offset: 20}},
+		{"escaped string", `RegionName = "Город \"N\""`, Query{Where: Eq("RegionName", `Город "N"`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			if !reflect.DeepEqual(q, tt.expected) {
+				t.Errorf("unexpected query:\n%#v\nexpected:\n%#v", q, tt.expected)
+			}
+
+			// Текстовое представление разбирается в тот же запрос
+			again, err := Parse(q.String())
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", q.String(), err)
+			}
+			if !reflect.DeepEqual(again, q) {
+				t.Errorf("round trip mismatch for %q", q.String())
+			}
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+	}{
+		{`RegionCode`, 10},
+		{`RegionCode = `, 13},
+		{`RegionCode = "013`, 13},
+		{`RegionCode > 1`, 11},
+		{`(RegionCode = "013"`, 19},
+		{`RegionCode = "013" LIMIT -1`, 25},
+		{`RegionCode = "013" @`, 19},
+		{`AND = 1`, 0},
+		{`OfficeType IN ()`, 15},
+		{`RegionName PREFIX 1`, 18},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%q: expected SyntaxError, got %v", tt.src, err)
+			continue
+		}
+		if syntaxErr.Pos != tt.pos {
+			t.Errorf("%q: unexpected position %d, expected %d (%v)", tt.src, syntaxErr.Pos, tt.pos, err)
+		}
+	}
+
+	if _, err := ParseExpr(""); err == nil {
+		t.Error("expected error for empty expression, got nil")
+	}
+	if _, err := ParseExpr("OfficeType = 1 LIMIT 1"); err == nil {
+		t.Error("expected error for LIMIT in expression, got nil")
+	}
+}
+
+func TestParse_limits(t *testing.T) {
+	nested := func(open string, n int) string {
+		return strings.Repeat(open, n) + "OfficeType = 1" + strings.Repeat(")", strings.Count(open, "(")*n)
+	}
+	tests := []struct {
+		name string
+		src  string
+		pos  int // Позиция ошибки, -1 - без ошибки
+	}{
+		{"max parens", nested("(", MaxDepth), -1},
+		{"too many parens", nested("(", MaxDepth+1), MaxDepth},
+		{"max not", nested("NOT ", MaxDepth), -1},
+		{"too many not", nested("NOT ", MaxDepth+1), 4 * MaxDepth},
+		{"mixed", nested("NOT (", MaxDepth/2+1), 5 * (MaxDepth / 2)},
+		{"unbalanced parens", strings.Repeat("(", MaxLength), MaxDepth},
+		{"too long", `RegionName = "` + strings.Repeat("a", MaxLength) + `"`, MaxLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			if tt.pos < 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected SyntaxError, got %v", err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("unexpected position %d, expected %d (%v)", syntaxErr.Pos, tt.pos, err)
+			}
+		})
+	}
+
+	if _, err := ParseExpr(nested("(", MaxDepth+1)); err == nil {
+		t.Error("expected error for nested expression, got nil")
+	}
+}
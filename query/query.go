@@ -0,0 +1,215 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Op - оператор условия на значение поля.
+type Op int
+
+const (
+	OpEq       Op = iota // Равно: Field = value
+	OpIn                 // Одно из значений: Field IN (v1, v2)
+	OpPrefix             // Строка начинается с: Field PREFIX "abc"
+	OpContains           // Строка содержит: Field CONTAINS "abc"
+	OpRange              // Диапазон включительно: Field BETWEEN from AND to, Field >= from, Field <= to
+)
+
+// String - возвращает название оператора.
+func (op Op) String() string {
+	switch op {
+	case OpEq:
+		return "="
+	case OpIn:
+		return "IN"
+	case OpPrefix:
+		return "PREFIX"
+	case OpContains:
+		return "CONTAINS"
+	case OpRange:
+		return "BETWEEN"
+	default:
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+}
+
+// Expr - условие отбора записей (узел синтаксического дерева).
+// Реализуется типами *Pred, *AndExpr, *OrExpr и *NotExpr.
+type Expr interface {
+	// String - возвращает условие в текстовом синтаксисе (см. Parse).
+	String() string
+	isExpr()
+}
+
+// Pred - условие на значение поля записи.
+// Поле задается атрибутом из тега esnsi (например, "RegionCode") или именем поля структуры.
+// Значения - string или int в соответствии с типом поля.
+type Pred struct {
+	Field  string // Поле записи
+	Op     Op     // Оператор
+	Values []any  // Значения. Для OpRange - [from, to], nil означает открытую границу
+}
+
+// AndExpr - все условия выполняются.
+type AndExpr struct {
+	Exprs []Expr
+}
+
+// OrExpr - хотя бы одно условие выполняется.
+type OrExpr struct {
+	Exprs []Expr
+}
+
+// NotExpr - условие не выполняется.
+type NotExpr struct {
+	Expr Expr
+}
+
+func (*Pred) isExpr()    {}
+func (*AndExpr) isExpr() {}
+func (*OrExpr) isExpr()  {}
+func (*NotExpr) isExpr() {}
+
+// Eq - поле field равно value.
+func Eq(field string, value any) Expr {
+	return &Pred{Field: field, Op: OpEq, Values: []any{value}}
+}
+
+// In - поле field равно одному из значений values.
+func In(field string, values ...any) Expr {
+	return &Pred{Field: field, Op: OpIn, Values: values}
+}
+
+// Prefix - строковое поле field начинается с prefix.
+func Prefix(field, prefix string) Expr {
+	return &Pred{Field: field, Op: OpPrefix, Values: []any{prefix}}
+}
+
+// Contains - строковое поле field содержит substr.
+func Contains(field, substr string) Expr {
+	return &Pred{Field: field, Op: OpContains, Values: []any{substr}}
+}
+
+// Range - поле field в диапазоне от from до to включительно.
+// Если from или to равно nil, граница диапазона не проверяется.
+func Range(field string, from, to any) Expr {
+	return &Pred{Field: field, Op: OpRange, Values: []any{from, to}}
+}
+
+// And - все условия exprs выполняются.
+func And(exprs ...Expr) Expr {
+	return &AndExpr{Exprs: exprs}
+}
+
+// Or - хотя бы одно из условий exprs выполняется.
+func Or(exprs ...Expr) Expr {
+	return &OrExpr{Exprs: exprs}
+}
+
+// Not - условие expr не выполняется.
+func Not(expr Expr) Expr {
+	return &NotExpr{Expr: expr}
+}
+
+func (p *Pred) String() string {
+	switch p.Op {
+	case OpIn:
+		vals := make([]string, len(p.Values))
+		for i, v := range p.Values {
+			vals[i] = literal(v)
+		}
+		return p.Field + " IN (" + strings.Join(vals, ", ") + ")"
+	case OpRange:
+		if len(p.Values) != 2 {
+			break
+		}
+		from, to := p.Values[0], p.Values[1]
+		switch {
+		case from != nil && to != nil:
+			return p.Field + " BETWEEN " + literal(from) + " AND " + literal(to)
+		case from != nil:
+			return p.Field + " >= " + literal(from)
+		case to != nil:
+			return p.Field + " <= " + literal(to)
+		}
+	default:
+		if len(p.Values) == 1 {
+			return p.Field + " " + p.Op.String() + " " + literal(p.Values[0])
+		}
+	}
+	return fmt.Sprintf("%s %s %v", p.Field, p.Op, p.Values)
+}
+
+func (e *AndExpr) String() string { return join(e.Exprs, " AND ") }
+func (e *OrExpr) String() string  { return join(e.Exprs, " OR ") }
+func (e *NotExpr) String() string { return "NOT " + group(e.Expr) }
+
+// join - соединяет условия оператором sep.
+func join(exprs []Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = group(e)
+	}
+	return strings.Join(parts, sep)
+}
+
+// group - заключает составное условие в скобки.
+func group(e Expr) string {
+	if _, ok := e.(*Pred); ok {
+		return e.String()
+	}
+	return "(" + e.String() + ")"
+}
+
+// literal - возвращает значение в текстовом синтаксисе.
+func literal(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Order - сортировка по полю записи.
+type Order struct {
+	Field string // Поле записи
+	Desc  bool   // По убыванию
+}
+
+// Query - запрос к записям классификатора.
+type Query struct {
+	Where   Expr    // Условие отбора. Если nil, отбираются все записи
+	OrderBy []Order // Сортировка. Если не задана, записи возвращаются в исходном порядке
+	Limit   int     // Максимальное количество записей. Если 0, не ограничено
+	Offset  int     // Количество пропускаемых записей
+}
+
+// String - возвращает запрос в текстовом синтаксисе (см. Parse).
+func (q Query) String() string {
+	var parts []string
+	if q.Where != nil {
+		parts = append(parts, q.Where.String())
+	}
+	if len(q.OrderBy) > 0 {
+		orders := make([]string, len(q.OrderBy))
+		for i, o := range q.OrderBy {
+			orders[i] = o.Field
+			if o.Desc {
+				orders[i] += " DESC"
+			}
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+	if q.Limit > 0 {
+		parts = append(parts, "LIMIT "+strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		parts = append(parts, "OFFSET "+strconv.Itoa(q.Offset))
+	}
+	return strings.Join(parts, " ")
+}
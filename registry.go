@@ -0,0 +1,227 @@
+package esnsi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrNotRegistered - классификатор с указанным именем не зарегистрирован.
+	ErrNotRegistered = errors.New("classifier not registered")
+	// ErrAlreadyRegistered - классификатор с указанным именем уже зарегистрирован.
+	ErrAlreadyRegistered = errors.New("classifier already registered")
+)
+
+// Source - источник данных классификатора для реестра.
+type Source struct {
+	path string                        // Путь к файлу
+	open func() (io.ReadCloser, error) // Функция открытия данных
+}
+
+// FromFile - источник данных из файла path.
+// При периодической проверке (Registry.Watch) классификатор перезагружается
+// только при изменении времени модификации или размера файла.
+func FromFile(path string) Source {
+	return Source{
+		path: path,
+		open: func() (io.ReadCloser, error) { return os.Open(path) },
+	}
+}
+
+// FromReader - источник данных из функции open, возвращающей новый поток при каждом вызове.
+// При периодической проверке (Registry.Watch) классификатор перезагружается каждый раз.
+func FromReader(open func() (io.ReadCloser, error)) Source {
+	return Source{open: open}
+}
+
+// ReloadEvent - результат перезагрузки классификатора.
+type ReloadEvent struct {
+	Name     string        // Имя классификатора в реестре
+	Err      error         // Ошибка перезагрузки. При ошибке сохраняется предыдущая версия классификатора
+	Duration time.Duration // Длительность перезагрузки
+}
+
+// Registry - потокобезопасный реестр именованных классификаторов с перезагрузкой.
+// Классификатор заменяется атомарно после полной загрузки, включая построение индексов,
+// поэтому читатели никогда не получают частично загруженный классификатор.
+// Если перезагрузка завершилась ошибкой, сохраняется предыдущая версия.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]registryEntry
+	pending map[string]bool // Имена классификаторов, загружаемых при регистрации
+	hooks   []func(ReloadEvent)
+}
+
+// registryEntry - классификатор в реестре.
+type registryEntry interface {
+	reload(force bool) (bool, error) // Перезагружает классификатор, возвращает true, если перезагрузка выполнялась
+	value() any                      // Текущая версия классификатора
+}
+
+// NewRegistry - создает пустой реестр классификаторов.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]registryEntry), pending: make(map[string]bool)}
+}
+
+// Register - регистрирует в реестре r классификатор name, загружаемый функцией load из источника src.
+// Функция load должна возвращать полностью готовый классификатор. Пример: classifiers.NewOkato.
+// Первая загрузка выполняется сразу, при ошибке классификатор не регистрируется.
+// Имя проверяется до загрузки: если оно уже зарегистрировано или регистрируется
+// в другой горутине, возвращается ошибка ErrAlreadyRegistered.
+func Register[T any](r *Registry, name string, src Source, load func(io.Reader) (*T, error)) error {
+	if r == nil || load == nil || src.open == nil {
+		return fmt.Errorf("nil pointer passed")
+	}
+
+	// Резервируем имя на время загрузки
+	r.mu.Lock()
+	if _, ok := r.entries[name]; ok || r.pending[name] {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrAlreadyRegistered, name)
+	}
+	r.pending[name] = true
+	r.mu.Unlock()
+
+	e := &entry[T]{src: src, load: load}
+	_, err := e.reload(true)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, name)
+	if err != nil {
+		return fmt.Errorf("failed to load classifier %s: %w", name, err)
+	}
+	r.entries[name] = e
+	return nil
+}
+
+// Get - возвращает текущую версию классификатора name из реестра r.
+// Возвращает false, если классификатор не зарегистрирован или имеет другой тип.
+func Get[T any](r *Registry, name string) (*T, bool) {
+	r.mu.RLock()
+	e, ok := r.entries[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	v, ok := e.value().(*T)
+	return v, ok
+}
+
+// OnReload - добавляет функцию, вызываемую после каждой перезагрузки классификатора,
+// в том числе завершившейся ошибкой.
+func (r *Registry) OnReload(hook func(ReloadEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// Reload - перезагружает классификатор name из источника.
+// При ошибке сохраняется предыдущая версия классификатора.
+func (r *Registry) Reload(name string) error {
+	r.mu.RLock()
+	e, ok := r.entries[name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+	return r.reload(name, e, true)
+}
+
+// Watch - проверяет источники классификаторов с интервалом interval и перезагружает
+// изменившиеся, пока не будет отменен контекст ctx. Ошибки перезагрузки передаются в OnReload.
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.mu.RLock()
+			entries := make(map[string]registryEntry, len(r.entries))
+			for name, e := range r.entries {
+				entries[name] = e
+			}
+			r.mu.RUnlock()
+			for name, e := range entries {
+				_ = r.reload(name, e, false)
+			}
+		}
+	}
+}
+
+// reload - перезагружает классификатор и вызывает функции OnReload.
+func (r *Registry) reload(name string, e registryEntry, force bool) error {
+	start := time.Now()
+	reloaded, err := e.reload(force)
+	if !reloaded {
+		return err
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to reload classifier %s: %w", name, err)
+	}
+
+	r.mu.RLock()
+	hooks := r.hooks
+	r.mu.RUnlock()
+	event := ReloadEvent{Name: name, Err: err, Duration: time.Since(start)}
+	for _, hook := range hooks {
+		hook(event)
+	}
+	return err
+}
+
+// entry - классификатор типа T в реестре.
+type entry[T any] struct {
+	mu      sync.Mutex // Последовательная перезагрузка
+	ptr     atomic.Pointer[T]
+	src     Source
+	load    func(io.Reader) (*T, error)
+	modTime time.Time // Время модификации файла при последней загрузке
+	size    int64     // Размер файла при последней загрузке
+}
+
+func (e *entry[T]) value() any {
+	return e.ptr.Load()
+}
+
+func (e *entry[T]) reload(force bool) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.src.path != "" {
+		info, err := os.Stat(e.src.path)
+		if err != nil {
+			return true, err
+		}
+		if !force && info.ModTime().Equal(e.modTime) && info.Size() == e.size {
+			return false, nil
+		}
+		// Запоминаем состояние файла и при ошибке, чтобы не повторять загрузку до следующего изменения
+		e.modTime, e.size = info.ModTime(), info.Size()
+	}
+
+	rc, err := e.src.open()
+	if err != nil {
+		return true, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer rc.Close()
+
+	v, err := e.load(rc)
+	if err != nil {
+		return true, err
+	}
+	if v == nil {
+		return true, fmt.Errorf("nil classifier loaded")
+	}
+	e.ptr.Store(v)
+	return true, nil
+}
@@ -0,0 +1,193 @@
+package esnsi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	src, err := os.ReadFile("testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "classifier.xml")
+	if err = os.WriteFile(path, src, 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	r := NewRegistry()
+	var (
+		mu     sync.Mutex
+		events []ReloadEvent
+	)
+	r.OnReload(func(e ReloadEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+	lastEvent := func() ReloadEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(events) == 0 {
+			return ReloadEvent{}
+		}
+		return events[len(events)-1]
+	}
+
+	if err = Register(r, "sfr", FromFile(path), loadTestClassifier); err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		c, ok := Get[Classifier[testRecord]](r, "sfr")
+		if !ok || c.Version != 55 || len(c.Records) != 4 {
+			t.Fatalf("unexpected classifier: %v, %+v", ok, c)
+		}
+		if _, ok = Get[Classifier[testRecordMeta]](r, "sfr"); ok {
+			t.Error("expected false for another record type")
+		}
+		if _, ok = Get[Classifier[testRecord]](r, "unknown"); ok {
+			t.Error("expected false for unknown classifier")
+		}
+	})
+
+	t.Run("register errors", func(t *testing.T) {
+		err := Register(r, "sfr", FromFile(path), loadTestClassifier)
+		if !errors.Is(err, ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+		}
+		if err = Register(r, "missing", FromFile(path+".missing"), loadTestClassifier); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if err = r.Reload("missing"); !errors.Is(err, ErrNotRegistered) {
+			t.Errorf("expected ErrNotRegistered, got %v", err)
+		}
+	})
+
+	t.Run("duplicate name is not loaded", func(t *testing.T) {
+		var loads atomic.Int32
+		load := func(rd io.Reader) (*Classifier[testRecord], error) {
+			loads.Add(1)
+			return loadTestClassifier(rd)
+		}
+		if err := Register(r, "sfr", FromFile(path), load); !errors.Is(err, ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+		}
+		if n := loads.Load(); n != 0 {
+			t.Errorf("unexpected number of loads: %d, expected 0", n)
+		}
+
+		// Одновременная регистрация одного имени: загружается и регистрируется один классификатор.
+		// Отдельный реестр, чтобы не влиять на проверки перезагрузки
+		r := NewRegistry()
+		release := make(chan struct{})
+		slow := func(rd io.Reader) (*Classifier[testRecord], error) {
+			loads.Add(1)
+			<-release
+			return loadTestClassifier(rd)
+		}
+		errs := make(chan error, 2)
+		go func() { errs <- Register(r, "concurrent", FromFile(path), slow) }()
+		for {
+			r.mu.RLock()
+			pending := r.pending["concurrent"]
+			r.mu.RUnlock()
+			if pending {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		go func() { errs <- Register(r, "concurrent", FromFile(path), slow) }()
+		if err := <-errs; !errors.Is(err, ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+		}
+		close(release)
+		if err := <-errs; err != nil {
+			t.Errorf("failed to register: %v", err)
+		}
+		if n := loads.Load(); n != 1 {
+			t.Errorf("unexpected number of loads: %d, expected 1", n)
+		}
+
+		// После ошибки загрузки имя освобождается
+		if err := Register(r, "retry", FromFile(path+".missing"), loadTestClassifier); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if err := Register(r, "retry", FromFile(path), loadTestClassifier); err != nil {
+			t.Errorf("failed to register after error: %v", err)
+		}
+	})
+
+	t.Run("failed reload keeps old version", func(t *testing.T) {
+		old, _ := Get[Classifier[testRecord]](r, "sfr")
+		if err := os.WriteFile(path, []byte("<broken"), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		if err := r.Reload("sfr"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if c, _ := Get[Classifier[testRecord]](r, "sfr"); c != old {
+			t.Error("expected old version to be kept")
+		}
+		if e := lastEvent(); e.Name != "sfr" || e.Err == nil {
+			t.Errorf("unexpected reload event: %+v", e)
+		}
+	})
+
+	t.Run("watch file", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go r.Watch(ctx, 5*time.Millisecond)
+
+		updated := strings.Replace(string(src), `version="55"`, `version="56"`, 1)
+		if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if c, _ := Get[Classifier[testRecord]](r, "sfr"); c.Version == 56 {
+				if e := lastEvent(); e.Err != nil {
+					t.Errorf("unexpected reload error: %v", e.Err)
+				}
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Error("expected classifier to be reloaded after file change")
+	})
+
+	t.Run("reader factory", func(t *testing.T) {
+		var opened atomic.Int32
+		open := func() (io.ReadCloser, error) {
+			opened.Add(1)
+			return io.NopCloser(bytes.NewReader(src)), nil
+		}
+		if err := Register(r, "reader", FromReader(open), loadTestClassifier); err != nil {
+			t.Fatalf("failed to register: %v", err)
+		}
+		if err := r.Reload("reader"); err != nil {
+			t.Fatalf("failed to reload: %v", err)
+		}
+		if opened.Load() != 2 {
+			t.Errorf("unexpected number of opens: %d, expected 2", opened.Load())
+		}
+	})
+}
+
+// loadTestClassifier - загружает тестовый классификатор из r.
+func loadTestClassifier(r io.Reader) (*Classifier[testRecord], error) {
+	c := &Classifier[testRecord]{}
+	if err := NewDecoder[testRecord](r).Decode(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,13 @@
+/*
+Package search предоставляет нечеткий поиск записей классификаторов ЕСНСИ по наименованиям.
+
+Текст записей и запросов нормализуется: приводится к нижнему регистру, "ё" заменяется на "е",
+сокращения раскрываются ("р-н" - "район", "г" - "город" и др., см. DefaultAbbreviations).
+Для поиска с опечатками используется индекс триграмм; результаты упорядочиваются по оценке соответствия.
+
+	ix := search.NewIndex(&okato.Classifier, func(rec *classifiers.OkatoRecord) string { return rec.Name })
+	for _, r := range ix.Search("алейский район", 10) {
+		fmt.Printf("%.2f %s %s\n", r.Score, r.Record.Code, r.Record.Name)
+	}
+*/
+package search
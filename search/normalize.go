@@ -0,0 +1,87 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultAbbreviations - сокращения, раскрываемые при нормализации текста.
+// Ключи - сокращения в нижнем регистре без завершающей точки, с "е" вместо "ё".
+var DefaultAbbreviations = map[string]string{
+	"р-н":   "район",
+	"р-на":  "района",
+	"р-не":  "районе",
+	"р-ну":  "району",
+	"р-ном": "районом",
+	"г":     "город",
+	"гор":   "город",
+	"обл":   "область",
+	"респ":  "республика",
+	"ао":    "автономный округ",
+	"п":     "поселок",
+	"пос":   "поселок",
+	"пгт":   "поселок городского типа",
+	"рп":    "рабочий поселок",
+	"с/с":   "сельсовет",
+	"с":     "село",
+	"д":     "деревня",
+	"дер":   "деревня",
+	"ст-ца": "станица",
+	"х":     "хутор",
+	"мкр":   "микрорайон",
+}
+
+// normalizer - нормализация текста для поиска.
+type normalizer struct {
+	abbr map[string]string // Сокращения
+}
+
+// tokens - возвращает слова нормализованного текста s:
+// нижний регистр, "ё" заменена на "е", сокращения раскрыты, знаки препинания удалены.
+func (n normalizer) tokens(s string) []string {
+	s = strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if r == 'ё' {
+			return 'е'
+		}
+		return r
+	}, s)
+
+	// Слова разделяются пробелами и знаками препинания, кроме "-" и "/" внутри сокращений
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '/'
+	})
+
+	var tokens []string
+	for _, w := range words {
+		if full, ok := n.abbr[w]; ok {
+			tokens = append(tokens, strings.Fields(full)...)
+			continue
+		}
+		for _, part := range strings.FieldsFunc(w, func(r rune) bool { return r == '-' || r == '/' }) {
+			if full, ok := n.abbr[part]; ok {
+				tokens = append(tokens, strings.Fields(full)...)
+			} else {
+				tokens = append(tokens, part)
+			}
+		}
+	}
+	return tokens
+}
+
+// Normalize - возвращает текст s, нормализованный для поиска с сокращениями DefaultAbbreviations.
+// Пример: "Сельсоветы Алейского р-на" -> "сельсоветы алейского района".
+func Normalize(s string) string {
+	return strings.Join(normalizer{abbr: DefaultAbbreviations}.tokens(s), " ")
+}
+
+// trigrams - добавляет в set триграммы слов tokens.
+// Слово дополняется двумя пробелами в начале и одним в конце, как в pg_trgm.
+func trigrams(tokens []string, set map[string]struct{}) {
+	for _, t := range tokens {
+		r := []rune("  " + t + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = struct{}{}
+		}
+	}
+}
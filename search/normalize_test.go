@@ -0,0 +1,20 @@
+package search
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Сельсоветы Алейского р-на":  "сельсоветы алейского района",
+		"г. Барнаул":                 "город барнаул",
+		"Ёлкинский с/с":              "елкинский сельсовет",
+		"пгт Яя":                     "поселок городского типа яя",
+		"Ханты-Мансийский АО":        "ханты мансийский автономный округ",
+		"  ОПФР по  Респ. Татарстан": "опфр по республика татарстан",
+		"": "",
+	}
+	for src, expected := range tests {
+		if got := Normalize(src); got != expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", src, got, expected)
+		}
+	}
+}
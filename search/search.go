@@ -0,0 +1,149 @@
+package search
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// DefaultMinScore - минимальная оценка результата поиска по умолчанию.
+const DefaultMinScore = 0.3
+
+// Option - параметр поискового индекса.
+type Option func(*options)
+
+// options - параметры поискового индекса.
+type options struct {
+	abbr     map[string]string // Сокращения
+	minScore float64           // Минимальная оценка результата
+}
+
+// WithAbbreviations - добавляет сокращения к DefaultAbbreviations или заменяет их расшифровку.
+// Ключи - сокращения в нижнем регистре без завершающей точки. Пример: {"с/п": "сельское поселение"}.
+func WithAbbreviations(abbr map[string]string) Option {
+	return func(o *options) {
+		maps.Copy(o.abbr, abbr)
+	}
+}
+
+// WithMinScore - задает минимальную оценку результата поиска от 0 до 1.
+// По умолчанию используется DefaultMinScore.
+func WithMinScore(score float64) Option {
+	return func(o *options) {
+		o.minScore = score
+	}
+}
+
+// Result - результат поиска.
+type Result[T any] struct {
+	Record *T      // Запись классификатора
+	Score  float64 // Оценка соответствия запросу от 0 до 1
+}
+
+// Index - поисковый индекс записей классификатора по тексту.
+// Индекс неизменяем после создания и безопасен для использования из нескольких горутин.
+type Index[T any] struct {
+	c        *esnsi.Classifier[T]
+	norm     normalizer
+	minScore float64
+	texts    []string           // Нормализованный текст записей
+	counts   []int              // Количество триграмм текста записей
+	postings map[string][]int32 // Номера записей по триграммам
+}
+
+// NewIndex - создает поисковый индекс записей классификатора c по тексту, возвращаемому функцией text.
+// Пример: func(rec *classifiers.OkatoRecord) string { return rec.Name }.
+func NewIndex[T any](c *esnsi.Classifier[T], text func(rec *T) string, opts ...Option) *Index[T] {
+	o := &options{abbr: maps.Clone(DefaultAbbreviations), minScore: DefaultMinScore}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ix := &Index[T]{
+		c:        c,
+		norm:     normalizer{abbr: o.abbr},
+		minScore: o.minScore,
+		texts:    make([]string, len(c.Records)),
+		counts:   make([]int, len(c.Records)),
+		postings: make(map[string][]int32),
+	}
+	set := make(map[string]struct{})
+	for i := range c.Records {
+		tokens := ix.norm.tokens(text(&c.Records[i]))
+		ix.texts[i] = strings.Join(tokens, " ")
+
+		clear(set)
+		trigrams(tokens, set)
+		ix.counts[i] = len(set)
+		for tg := range set {
+			ix.postings[tg] = append(ix.postings[tg], int32(i))
+		}
+	}
+	return ix
+}
+
+// Search - ищет записи по тексту q с учетом регистра, "ё", сокращений и опечаток.
+// Возвращает не более limit результатов (все, если limit <= 0) в порядке убывания оценки,
+// при равной оценке - в порядке записей классификатора.
+//
+// Оценка - доля триграмм запроса, найденных в тексте записи, с поправкой на длину текста.
+// Точное совпадение нормализованного текста оценивается в 1, вхождение запроса в текст - не ниже 0.9.
+func (ix *Index[T]) Search(q string, limit int) []Result[T] {
+	tokens := ix.norm.tokens(q)
+	if len(tokens) == 0 {
+		return nil
+	}
+	query := strings.Join(tokens, " ")
+	set := make(map[string]struct{})
+	trigrams(tokens, set)
+
+	// Количество общих триграмм по записям
+	shared := make(map[int32]int)
+	for tg := range set {
+		for _, i := range ix.postings[tg] {
+			shared[i]++
+		}
+	}
+
+	type match struct {
+		i     int32
+		score float64
+	}
+	var matches []match
+	for i, n := range shared {
+		coverage := float64(n) / float64(len(set))
+		dice := 2 * float64(n) / float64(len(set)+ix.counts[i])
+		score := 0.7*coverage + 0.3*dice
+		switch text := ix.texts[i]; {
+		case text == query:
+			score = 1
+		case containsWords(text, query):
+			score = max(score, 0.9+0.09*dice)
+		}
+		if score >= ix.minScore {
+			matches = append(matches, match{i: i, score: score})
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return matches[a].i < matches[b].i
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	res := make([]Result[T], len(matches))
+	for k, m := range matches {
+		res[k] = Result[T]{Record: &ix.c.Records[m.i], Score: m.score}
+	}
+	return res
+}
+
+// containsWords - проверяет, что text содержит слова query подряд.
+func containsWords(text, query string) bool {
+	return strings.Contains(" "+text+" ", " "+query+" ")
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+func TestIndex_Search(t *testing.T) {
+	c := &esnsi.Classifier[testRecord]{Records: []testRecord{
+		{Code: "01", Name: "Алтайский край"},
+		{Code: "01.200", Name: "Районы Алтайского края"},
+		{Code: "01.201.800", Name: "Сельсоветы Алейского р-на"},
+		{Code: "01.201.802.002", Name: "с Малахово"},
+		{Code: "01.401", Name: "г Барнаул"},
+		{Code: "01.401.365", Name: "Ёлкинский сельсовет"},
+	}}
+	ix := NewIndex(c, func(rec *testRecord) string { return rec.Name })
+
+	tests := []struct {
+		query    string
+		expected string // Код первой записи
+	}{
+		{"Барнаул", "01.401"},
+		{"город Барнаул", "01.401"},
+		{"барнуал", "01.401"},
+		{"алейский район", "01.201.800"},
+		{"Сельсоветы Алейского района", "01.201.800"},
+		{"елкинский", "01.401.365"},
+		{"село Малахово", "01.201.802.002"},
+		{"АЛТАЙСКИЙ КРАЙ", "01"},
+	}
+	for _, tt := range tests {
+		res := ix.Search(tt.query, 3)
+		if len(res) == 0 {
+			t.Errorf("%q: no results", tt.query)
+			continue
+		}
+		if res[0].Record.Code != tt.expected {
+			t.Errorf("%q: unexpected first result %s (%.2f), expected %s", tt.query, res[0].Record.Code, res[0].Score, tt.expected)
+		}
+	}
+
+	t.Run("ranking", func(t *testing.T) {
+		res := ix.Search("алтайский край", 0)
+		if len(res) < 2 {
+			t.Fatalf("expected at least 2 results, got %d", len(res))
+		}
+		if res[0].Score != 1 {
+			t.Errorf("expected score 1 for exact match, got %.2f", res[0].Score)
+		}
+		for i := 1; i < len(res); i++ {
+			if res[i].Score > res[i-1].Score {
+				t.Errorf("results not sorted by score: %.2f > %.2f", res[i].Score, res[i-1].Score)
+			}
+		}
+		if res[0].Record != &c.Records[0] {
+			t.Error("expected pointer to classifier record")
+		}
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		if res := ix.Search("Владивосток", 0); len(res) != 0 {
+			t.Errorf("unexpected results: %d", len(res))
+		}
+		if res := ix.Search(" .,", 0); res != nil {
+			t.Errorf("unexpected results for empty query: %d", len(res))
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		ix := NewIndex(c, func(rec *testRecord) string { return rec.Name },
+			WithAbbreviations(map[string]string{"бна": "барнаул"}),
+			WithMinScore(0.95),
+		)
+		res := ix.Search("г бна", 0)
+		if len(res) != 1 || res[0].Record.Code != "01.401" {
+			t.Errorf("unexpected results: %+v", res)
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	Code string
+	Name string
+}
@@ -0,0 +1,149 @@
+package esnsi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// Формат снимка классификатора:
+//
+//	magic (8 байт) | версия формата (uint16, big-endian) | gob(snapshotHeader) | gob([]T)
+const (
+	snapshotMagic   = "ESNSISNP"
+	snapshotVersion = 1
+)
+
+var (
+	// ErrSnapshotFormat - данные не являются снимком классификатора или версия формата не поддерживается.
+	ErrSnapshotFormat = errors.New("invalid snapshot format")
+	// ErrSnapshotType - тип записей снимка не совпадает с типом записей классификатора.
+	ErrSnapshotType = errors.New("snapshot record type mismatch")
+)
+
+// snapshotHeader - заголовок снимка классификатора.
+type snapshotHeader struct {
+	Meta            Meta   // Метаданные классификатора
+	TypeName        string // Тип записей
+	TypeFingerprint uint64 // Отпечаток структуры типа записей
+	Count           int    // Количество записей
+}
+
+// WriteSnapshot - записывает классификатор c в w в бинарном формате снимка.
+// Снимок загружается функцией ReadSnapshot значительно быстрее разбора XML.
+func WriteSnapshot[T any](w io.Writer, c *Classifier[T]) error {
+	if c == nil {
+		return fmt.Errorf("nil pointer passed")
+	}
+	typ := reflect.TypeOf(*new(T))
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(snapshotMagic); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := binary.Write(bw, binary.BigEndian, uint16(snapshotVersion)); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	enc := gob.NewEncoder(bw)
+	header := snapshotHeader{
+		Meta:            c.Meta,
+		TypeName:        typ.String(),
+		TypeFingerprint: typeFingerprint(typ),
+		Count:           len(c.Records),
+	}
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	if err := enc.Encode(c.Records); err != nil {
+		return fmt.Errorf("failed to write snapshot records: %w", err)
+	}
+	return bw.Flush()
+}
+
+// ReadSnapshot - читает снимок классификатора из r, записанный функцией WriteSnapshot.
+// Если структура типа записей T изменилась после записи снимка, возвращает ошибку ErrSnapshotType.
+// При ошибке классификатор c не изменяется.
+func ReadSnapshot[T any](r io.Reader, c *Classifier[T]) error {
+	if c == nil {
+		return fmt.Errorf("nil pointer passed")
+	}
+	if r == nil {
+		return fmt.Errorf("reader is nil")
+	}
+	typ := reflect.TypeOf(*new(T))
+
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != snapshotMagic {
+		return ErrSnapshotFormat
+	}
+	var version uint16
+	if err := binary.Read(br, binary.BigEndian, &version); err != nil {
+		return ErrSnapshotFormat
+	}
+	if version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrSnapshotFormat, version)
+	}
+
+	dec := gob.NewDecoder(br)
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("%w: failed to read header: %v", ErrSnapshotFormat, err)
+	}
+	if header.TypeName != typ.String() || header.TypeFingerprint != typeFingerprint(typ) {
+		return fmt.Errorf("%w: snapshot has %s, expected %s", ErrSnapshotType, header.TypeName, typ)
+	}
+
+	var records []T
+	if err := dec.Decode(&records); err != nil {
+		return fmt.Errorf("%w: failed to read records: %v", ErrSnapshotFormat, err)
+	}
+	if len(records) != header.Count {
+		return fmt.Errorf("%w: expected %d records, got %d", ErrSnapshotFormat, header.Count, len(records))
+	}
+
+	c.Meta = header.Meta
+	c.Records = records
+	return nil
+}
+
+// typeFingerprint - возвращает отпечаток структуры типа записей:
+// хеш имен, типов и тегов полей, включая поля вложенных структур
+// и типы элементов срезов, массивов, словарей и указателей.
+func typeFingerprint(typ reflect.Type) uint64 {
+	h := newFnv64()
+	fingerprintType(&h, typ, make(map[reflect.Type]bool))
+	return uint64(h)
+}
+
+// fingerprintType - добавляет в хеш h описание типа typ.
+// Уже пройденные типы seen добавляются только по имени, что исключает зацикливание на рекурсивных типах.
+func fingerprintType(h *fnv64, typ reflect.Type, seen map[reflect.Type]bool) {
+	h.add(typ.PkgPath(), typ.String(), typ.Kind().String())
+	if seen[typ] {
+		return
+	}
+	seen[typ] = true
+	switch typ.Kind() {
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			h.add(field.Name, string(field.Tag))
+			fingerprintType(h, field.Type, seen)
+		}
+	case reflect.Map:
+		fingerprintType(h, typ.Key(), seen)
+		fingerprintType(h, typ.Elem(), seen)
+	case reflect.Array:
+		h.add(strconv.Itoa(typ.Len()))
+		fingerprintType(h, typ.Elem(), seen)
+	case reflect.Slice, reflect.Pointer:
+		fingerprintType(h, typ.Elem(), seen)
+	}
+}
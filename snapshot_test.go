@@ -0,0 +1,184 @@
+package esnsi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+//goland:noinspection GoUnhandledErrorResult
+func TestSnapshot(t *testing.T) {
+	f, err := os.Open("testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	src := &Classifier[testRecord]{}
+	if err = NewDecoder[testRecord](f).Decode(src); err != nil {
+		t.Fatalf("failed to decode classifier: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = WriteSnapshot(&buf, src); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		dst := &Classifier[testRecord]{}
+		if err := ReadSnapshot(bytes.NewReader(buf.Bytes()), dst); err != nil {
+			t.Fatalf("failed to read snapshot: %v", err)
+		}
+		if !reflect.DeepEqual(src, dst) {
+			t.Errorf("unexpected classifier:\n%+v\nexpected:\n%+v", dst, src)
+		}
+	})
+
+	t.Run("empty classifier", func(t *testing.T) {
+		var empty bytes.Buffer
+		if err := WriteSnapshot(&empty, &Classifier[testRecord]{Meta: Meta{Code: "Empty"}}); err != nil {
+			t.Fatalf("failed to write snapshot: %v", err)
+		}
+		dst := &Classifier[testRecord]{}
+		if err := ReadSnapshot(&empty, dst); err != nil {
+			t.Fatalf("failed to read snapshot: %v", err)
+		}
+		if dst.Code != "Empty" || len(dst.Records) != 0 {
+			t.Errorf("unexpected classifier: %+v", dst)
+		}
+	})
+
+	t.Run("record type changed", func(t *testing.T) {
+		dst := &Classifier[testRecordMeta]{}
+		err := ReadSnapshot(bytes.NewReader(buf.Bytes()), dst)
+		if !errors.Is(err, ErrSnapshotType) {
+			t.Errorf("expected ErrSnapshotType, got %v", err)
+		}
+		if dst.Code != "" {
+			t.Error("classifier must not be changed on error")
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"empty":       nil,
+			"xml":         []byte("<?xml version='1.0' encoding='UTF-8'?>"),
+			"version":     []byte(snapshotMagic + "\x00\x02"),
+			"truncated":   buf.Bytes()[:buf.Len()/2],
+			"no sections": []byte(snapshotMagic + "\x00\x01"),
+		} {
+			err := ReadSnapshot(bytes.NewReader(data), &Classifier[testRecord]{})
+			if !errors.Is(err, ErrSnapshotFormat) {
+				t.Errorf("%s: expected ErrSnapshotFormat, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestTypeFingerprint(t *testing.T) {
+	// Типы объявлены в разных функциях и имеют одинаковые имена:
+	// отличается только структура вложенного типа address
+	oldType := func() reflect.Type {
+		type address struct{ City string }
+		type record struct {
+			Name      string
+			Address   address
+			Addresses []address
+			ByCode    map[string]*address
+		}
+		return reflect.TypeOf(record{})
+	}()
+	newType := func() reflect.Type {
+		type address struct {
+			City string
+			Zip  int
+		}
+		type record struct {
+			Name      string
+			Address   address
+			Addresses []address
+			ByCode    map[string]*address
+		}
+		return reflect.TypeOf(record{})
+	}()
+	sameType := func() reflect.Type {
+		type address struct{ City string }
+		type record struct {
+			Name      string
+			Address   address
+			Addresses []address
+			ByCode    map[string]*address
+		}
+		return reflect.TypeOf(record{})
+	}()
+
+	t.Run("nested struct changed", func(t *testing.T) {
+		if oldType.String() != newType.String() {
+			t.Fatalf("type names must be equal: %s, %s", oldType, newType)
+		}
+		if typeFingerprint(oldType) == typeFingerprint(newType) {
+			t.Error("expected different fingerprints")
+		}
+	})
+
+	t.Run("element types", func(t *testing.T) {
+		for i := 1; i < oldType.NumField(); i++ {
+			oldField, newField := oldType.Field(i).Type, newType.Field(i).Type
+			if typeFingerprint(oldField) == typeFingerprint(newField) {
+				t.Errorf("%s: expected different fingerprints", oldField)
+			}
+		}
+	})
+
+	t.Run("same structure", func(t *testing.T) {
+		if typeFingerprint(oldType) != typeFingerprint(sameType) {
+			t.Error("expected equal fingerprints")
+		}
+	})
+
+	t.Run("recursive type", func(t *testing.T) {
+		type node struct {
+			Name     string
+			Children []*node
+		}
+		if typeFingerprint(reflect.TypeOf(node{})) == 0 {
+			t.Error("expected non-zero fingerprint")
+		}
+	})
+}
+
+// BenchmarkReadSnapshot - загрузка снимка в сравнении с разбором XML.
+func BenchmarkReadSnapshot(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		data := genTestXML(b, n)
+		c := &Classifier[testRecord]{}
+		if err := NewDecoder[testRecord](bytes.NewReader(data)).Decode(c); err != nil {
+			b.Fatal(err)
+		}
+		var snapshot bytes.Buffer
+		if err := WriteSnapshot(&snapshot, c); err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("snapshot/records=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := ReadSnapshot(bytes.NewReader(snapshot.Bytes()), &Classifier[testRecord]{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("xml/records=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := NewDecoder[testRecord](bytes.NewReader(data)).Decode(&Classifier[testRecord]{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package sqlgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// Dialect - диалект SQL.
+type Dialect int
+
+const (
+	Postgres Dialect = iota // PostgreSQL
+	SQLite                  // SQLite
+)
+
+// String - возвращает название диалекта.
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case SQLite:
+		return "sqlite"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// columnType - возвращает тип колонки для атрибута классификатора.
+func (d Dialect) columnType(attr esnsi.Attribute) (string, error) {
+	switch d {
+	case Postgres:
+		switch attr.Type {
+		case esnsi.AttrString:
+			if attr.Length > 0 {
+				return fmt.Sprintf("varchar(%d)", attr.Length), nil
+			}
+			return "text", nil
+		case esnsi.AttrText, esnsi.AttrReference:
+			return "text", nil
+		case esnsi.AttrInteger:
+			return "bigint", nil
+		case esnsi.AttrDate:
+			return "date", nil
+		case esnsi.AttrDecimal:
+			return "numeric", nil
+		case esnsi.AttrBoolean:
+			return "boolean", nil
+		}
+	case SQLite:
+		switch attr.Type {
+		case esnsi.AttrString:
+			if attr.Length > 0 {
+				return fmt.Sprintf("VARCHAR(%d)", attr.Length), nil
+			}
+			return "TEXT", nil
+		case esnsi.AttrText, esnsi.AttrReference, esnsi.AttrDate:
+			return "TEXT", nil
+		case esnsi.AttrInteger, esnsi.AttrBoolean:
+			return "INTEGER", nil
+		case esnsi.AttrDecimal:
+			return "NUMERIC", nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported dialect %s", d)
+	}
+	return "", fmt.Errorf("unsupported attribute type '%s' for attribute %s", attr.Type, attr.Name)
+}
+
+// Quote - заключает идентификатор в двойные кавычки.
+func (d Dialect) Quote(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// Placeholder - возвращает параметр запроса с номером n (начиная с 1).
+// Пример: "$1" для PostgreSQL, "?" для SQLite.
+func (d Dialect) Placeholder(n int) string {
+	if d == Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// literal - возвращает значение в виде литерала SQL.
+func (d Dialect) literal(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case int:
+		return fmt.Sprint(v)
+	case bool:
+		if d == SQLite {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return d.literal(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Package sqlgen формирует SQL-скрипты для загрузки классификаторов ЕСНСИ в базу данных.
+
+Описание таблицы (Table) строится по схеме классификатора:
+
+  - строка с длиной - varchar(n), без длины - text
+  - целое число - bigint, дата - date, десятичное число - numeric, логическое значение - boolean
+  - обязательный атрибут - NOT NULL, уникальный - UNIQUE
+  - ключевой атрибут классификатора (key-attribute-ref) - PRIMARY KEY
+
+Записи выгружаются пакетными операторами INSERT или командой COPY ... FROM STDIN
+в текстовом формате (только PostgreSQL). Поддерживаются диалекты PostgreSQL и SQLite.
+
+# Пример использования
+
+	package main
+
+	import (
+		"log"
+		"os"
+
+		"github.com/ofstudio/go-esnsi/classifiers"
+		"github.com/ofstudio/go-esnsi/sqlgen"
+	)
+
+	func main() {
+		file, err := os.Open("SFR_CO_55UTF-8.xml")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+
+		sfr, err := classifiers.NewSfr(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		table, err := sqlgen.NewTable(&sfr.Classifier, "sfr", sqlgen.Postgres)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err = table.WriteCreate(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		if err = table.WriteCopy(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	}
+*/
+package sqlgen
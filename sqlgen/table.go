@@ -0,0 +1,271 @@
+package sqlgen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+// Option - параметр описания таблицы.
+type Option func(*options)
+
+// options - параметры описания таблицы.
+type options struct {
+	columnNames map[string]string // Имена колонок по атрибутам
+}
+
+// WithColumnNames - задает имена колонок по атрибутам из тегов esnsi
+// (для записей esnsi.DynamicRecord - по наименованиям атрибутов).
+// По умолчанию имя колонки совпадает с атрибутом.
+func WithColumnNames(names map[string]string) Option {
+	return func(o *options) {
+		o.columnNames = names
+	}
+}
+
+// Table - описание таблицы SQL для классификатора с записями типа T.
+type Table[T any] struct {
+	Name    string   // Имя таблицы
+	Dialect Dialect  // Диалект SQL
+	Columns []Column // Колонки
+	c       *esnsi.Classifier[T]
+	values  []func(rec *T) any // Функции получения значений колонок
+}
+
+// Column - колонка таблицы.
+type Column struct {
+	Name       string          // Имя колонки
+	Type       string          // Тип колонки. Пример: "varchar(255)"
+	NotNull    bool            // Атрибут обязательный
+	Unique     bool            // Атрибут уникальный
+	PrimaryKey bool            // Атрибут ключевой (key-attribute-ref)
+	Attribute  esnsi.Attribute // Атрибут классификатора
+}
+
+// NewTable - создает описание таблицы name для классификатора c в диалекте d.
+// Колонки соответствуют полям с тегом esnsi типа записи T, а для записей esnsi.DynamicRecord -
+// всем атрибутам классификатора. Типы колонок определяются по схеме классификатора (c.Attributes).
+func NewTable[T any](c *esnsi.Classifier[T], name string, d Dialect, opts ...Option) (*Table[T], error) {
+	if c == nil {
+		return nil, fmt.Errorf("nil pointer passed")
+	}
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	t := &Table[T]{Name: name, Dialect: d, c: c}
+	add := func(key string, attr esnsi.Attribute, value func(rec *T) any) error {
+		typ, err := d.columnType(attr)
+		if err != nil {
+			return err
+		}
+		colName := key
+		if n, ok := o.columnNames[key]; ok {
+			colName = n
+		}
+		t.Columns = append(t.Columns, Column{
+			Name:       colName,
+			Type:       typ,
+			NotNull:    attr.Required,
+			Unique:     attr.Unique,
+			PrimaryKey: attr.UID != "" && attr.UID == c.KeyAttributeRef,
+			Attribute:  attr,
+		})
+		t.values = append(t.values, value)
+		return nil
+	}
+
+	if _, ok := any(c).(*esnsi.Classifier[esnsi.DynamicRecord]); ok {
+		// Записи произвольной структуры: все атрибуты классификатора
+		for _, attr := range c.Attributes {
+			if err := add(attr.Name, attr, func(rec *T) any {
+				return any(rec).(*esnsi.DynamicRecord).Values[attr.Name]
+			}); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		// Поля с тегом esnsi
+		fields, err := esnsi.Fields[T]()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			attr, ok := findAttr(c.Attributes, f)
+			if !ok && f.Optional {
+				// Необязательный атрибут отсутствует в классификаторе
+				continue
+			}
+			if !ok {
+				return nil, fmt.Errorf("attribute %s not found in classifier", f.Attr)
+			}
+			if err = add(f.Attr, attr, func(rec *T) any { return f.Value(rec) }); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(t.Columns) == 0 {
+		return nil, fmt.Errorf("no columns for table %s", name)
+	}
+	return t, nil
+}
+
+// findAttr - ищет атрибут для поля f: сначала способом сопоставления из тега,
+// затем по наименованию, техническому наименованию и идентификатору.
+func findAttr(attrs []esnsi.Attribute, f esnsi.Field) (esnsi.Attribute, bool) {
+	key := func(a esnsi.Attribute, m esnsi.MatchMode) string {
+		switch m {
+		case esnsi.MatchByTechName:
+			return a.TechName
+		case esnsi.MatchByUID:
+			return a.UID
+		default:
+			return a.Name
+		}
+	}
+	for _, m := range []esnsi.MatchMode{f.Match, esnsi.MatchByName, esnsi.MatchByTechName, esnsi.MatchByUID} {
+		for _, a := range attrs {
+			if key(a, m) == f.Attr {
+				return a, true
+			}
+		}
+	}
+	return esnsi.Attribute{}, false
+}
+
+// Values - возвращает значения колонок записи rec.
+func (t *Table[T]) Values(rec *T) []any {
+	vals := make([]any, len(t.values))
+	for i, value := range t.values {
+		vals[i] = value(rec)
+	}
+	return vals
+}
+
+// QuotedName - возвращает имя таблицы в кавычках.
+func (t *Table[T]) QuotedName() string {
+	return t.Dialect.Quote(t.Name)
+}
+
+// QuotedColumns - возвращает имена колонок в кавычках через запятую.
+func (t *Table[T]) QuotedColumns() string {
+	names := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		names[i] = t.Dialect.Quote(col.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// WriteCreate - записывает в w оператор CREATE TABLE.
+func (t *Table[T]) WriteCreate(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(t.QuotedName())
+	b.WriteString(" (\n")
+	for i, col := range t.Columns {
+		b.WriteString("    ")
+		b.WriteString(t.Dialect.Quote(col.Name))
+		b.WriteString(" ")
+		b.WriteString(col.Type)
+		switch {
+		case col.PrimaryKey:
+			b.WriteString(" PRIMARY KEY")
+		case col.Unique:
+			if col.NotNull {
+				b.WriteString(" NOT NULL")
+			}
+			b.WriteString(" UNIQUE")
+		case col.NotNull:
+			b.WriteString(" NOT NULL")
+		}
+		if i < len(t.Columns)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(");\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// WriteInsert - записывает в w записи классификатора операторами INSERT
+// по batchSize записей в каждом. Если batchSize <= 0, используется 500.
+func (t *Table[T]) WriteInsert(w io.Writer, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	bw := bufio.NewWriter(w)
+	header := "INSERT INTO " + t.QuotedName() + " (" + t.QuotedColumns() + ") VALUES\n"
+	for i := range t.c.Records {
+		if i%batchSize == 0 {
+			if i > 0 {
+				_, _ = bw.WriteString(";\n")
+			}
+			_, _ = bw.WriteString(header)
+		} else {
+			_, _ = bw.WriteString(",\n")
+		}
+		_, _ = bw.WriteString("    (")
+		for j, v := range t.Values(&t.c.Records[i]) {
+			if j > 0 {
+				_, _ = bw.WriteString(", ")
+			}
+			_, _ = bw.WriteString(t.Dialect.literal(v))
+		}
+		_, _ = bw.WriteString(")")
+	}
+	if len(t.c.Records) > 0 {
+		_, _ = bw.WriteString(";\n")
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// WriteCopy - записывает в w записи классификатора командой COPY ... FROM STDIN
+// в текстовом формате PostgreSQL. Поддерживается только диалектом Postgres.
+func (t *Table[T]) WriteCopy(w io.Writer) error {
+	if t.Dialect != Postgres {
+		return fmt.Errorf("COPY is not supported by dialect %s", t.Dialect)
+	}
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("COPY " + t.QuotedName() + " (" + t.QuotedColumns() + ") FROM STDIN;\n")
+	for i := range t.c.Records {
+		for j, v := range t.Values(&t.c.Records[i]) {
+			if j > 0 {
+				_ = bw.WriteByte('\t')
+			}
+			_, _ = bw.WriteString(copyValue(v))
+		}
+		_ = bw.WriteByte('\n')
+	}
+	_, _ = bw.WriteString("\\.\n")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	return nil
+}
+
+// copyReplacer - экранирование значений для текстового формата COPY
+var copyReplacer = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// copyValue - возвращает значение в текстовом формате COPY.
+func copyValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return `\N`
+	case string:
+		return copyReplacer.Replace(v)
+	default:
+		return copyReplacer.Replace(fmt.Sprint(v))
+	}
+}
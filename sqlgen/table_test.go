@@ -0,0 +1,221 @@
+package sqlgen
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ofstudio/go-esnsi"
+)
+
+func TestNewTable(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+
+	t.Run("columns", func(t *testing.T) {
+		table, err := NewTable(c, "sfr", Postgres, WithColumnNames(map[string]string{"ToSfrCode": "code"}))
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		expected := []string{"code varchar(3)", "RegionName varchar(255)", "OfficeType bigint"}
+		if len(table.Columns) != len(expected) {
+			t.Fatalf("unexpected number of columns: %d, expected %d", len(table.Columns), len(expected))
+		}
+		for i, col := range table.Columns {
+			if got := col.Name + " " + col.Type; got != expected[i] {
+				t.Errorf("unexpected column %d: '%s', expected '%s'", i, got, expected[i])
+			}
+			if !col.NotNull {
+				t.Errorf("expected column %s to be NOT NULL", col.Name)
+			}
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		_, err := NewTable(&esnsi.Classifier[testRecord]{}, "sfr", Postgres)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "attribute ToSfrCode not found in classifier") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("optional attribute", func(t *testing.T) {
+		type record struct {
+			ToSfrCode string `esnsi:"ToSfrCode"`
+			Missing   string `esnsi:"Missing,optional"`
+		}
+		table, err := NewTable(loadTestClassifier[record](t), "sfr", Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		if len(table.Columns) != 1 || table.Columns[0].Name != "ToSfrCode" {
+			t.Errorf("unexpected columns: %+v", table.Columns)
+		}
+	})
+}
+
+func TestTable_WriteCreate(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+	c.KeyAttributeRef = c.Attributes[0].UID
+
+	t.Run("postgres", func(t *testing.T) {
+		table, err := NewTable(c, "sfr", Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		var buf bytes.Buffer
+		if err = table.WriteCreate(&buf); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		expected := `CREATE TABLE "sfr" (
+    "ToSfrCode" varchar(3) PRIMARY KEY,
+    "RegionName" varchar(255) NOT NULL,
+    "OfficeType" bigint NOT NULL
+);
+`
+		if buf.String() != expected {
+			t.Errorf("unexpected DDL:\n%s\nexpected:\n%s", buf.String(), expected)
+		}
+	})
+
+	t.Run("sqlite dynamic", func(t *testing.T) {
+		d := loadTestClassifier[esnsi.DynamicRecord](t)
+		d.Attributes[1].Unique = true
+		table, err := NewTable(d, "sfr", SQLite)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		var buf bytes.Buffer
+		if err = table.WriteCreate(&buf); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		expected := `CREATE TABLE "sfr" (
+    "ToSfrCode" VARCHAR(3) NOT NULL,
+    "RegionCode" VARCHAR(3) NOT NULL UNIQUE,
+    "RegionName" VARCHAR(255) NOT NULL,
+    "OfficeType" INTEGER NOT NULL
+);
+`
+		if buf.String() != expected {
+			t.Errorf("unexpected DDL:\n%s\nexpected:\n%s", buf.String(), expected)
+		}
+	})
+}
+
+func TestTable_WriteInsert(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+	c.Records[1].RegionName = "Город 'N'"
+	table, err := NewTable(c, "sfr", Postgres)
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = table.WriteInsert(&buf, 3); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	expected := `INSERT INTO "sfr" ("ToSfrCode", "RegionName", "OfficeType") VALUES
+    ('210', 'Республика Татарстан', 2),
+    ('201', 'Город ''N''', 2),
+    ('059', 'Магаданская область', 2);
+INSERT INTO "sfr" ("ToSfrCode", "RegionName", "OfficeType") VALUES
+    ('041', 'Белгородская область', 2);
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected INSERT:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		empty, err := NewTable(&esnsi.Classifier[testRecord]{Meta: c.Meta}, "sfr", Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		var buf bytes.Buffer
+		if err = empty.WriteInsert(&buf, 0); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected empty output, got %q", buf.String())
+		}
+	})
+}
+
+func TestTable_WriteCopy(t *testing.T) {
+	c := loadTestClassifier[testRecord](t)
+	c.Records = c.Records[:2]
+	c.Records[1].RegionName = "Москва\tстолица\\"
+
+	t.Run("postgres", func(t *testing.T) {
+		table, err := NewTable(c, "sfr", Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		var buf bytes.Buffer
+		if err = table.WriteCopy(&buf); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		expected := "COPY \"sfr\" (\"ToSfrCode\", \"RegionName\", \"OfficeType\") FROM STDIN;\n" +
+			"210\tРеспублика Татарстан\t2\n" +
+			"201\tМосква\\tстолица\\\\\t2\n" +
+			"\\.\n"
+		if buf.String() != expected {
+			t.Errorf("unexpected COPY:\n%q\nexpected:\n%q", buf.String(), expected)
+		}
+	})
+
+	t.Run("dynamic null", func(t *testing.T) {
+		d := loadTestClassifier[esnsi.DynamicRecord](t)
+		d.Records = d.Records[:1]
+		delete(d.Records[0].Values, "RegionCode")
+		table, err := NewTable(d, "sfr", Postgres)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		var buf bytes.Buffer
+		if err = table.WriteCopy(&buf); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		if !strings.Contains(buf.String(), "210\t\\N\tРеспублика Татарстан\t2\n") {
+			t.Errorf("unexpected COPY: %q", buf.String())
+		}
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		table, err := NewTable(c, "sfr", SQLite)
+		if err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+		if err = table.WriteCopy(&bytes.Buffer{}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+// testRecord - тестовая запись классификатора
+type testRecord struct {
+	UID        string `esnsi:",uid"`
+	ToSfrCode  string `esnsi:"ToSfrCode"`
+	RegionName string `esnsi:"RegionName"`
+	OfficeType int    `esnsi:"OfficeType"`
+	TestField  string
+}
+
+// loadTestClassifier - загружает тестовый классификатор с записями типа T.
+//
+//goland:noinspection GoUnhandledErrorResult
+func loadTestClassifier[T any](t *testing.T) *esnsi.Classifier[T] {
+	t.Helper()
+	f, err := os.Open("../testdata/decoder-valid_test.xml")
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	c := &esnsi.Classifier[T]{}
+	if err = esnsi.NewDecoder[T](f).Decode(c); err != nil {
+		t.Fatalf("failed to decode classifier: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,137 @@
+package esnsi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MatchMode - способ сопоставления тега esnsi с атрибутом классификатора.
+type MatchMode int
+
+const (
+	MatchByName     MatchMode = iota // По наименованию атрибута (name), например "Дополнительные данные"
+	MatchByTechName                  // По техническому наименованию атрибута (tech-name), например "additional_data"
+	MatchByUID                       // По идентификатору атрибута (uid), например "51fa43b9-5de3-4add-ae76-dd4c9d737bbe"
+)
+
+// String - возвращает название способа сопоставления.
+func (m MatchMode) String() string {
+	switch m {
+	case MatchByName:
+		return "name"
+	case MatchByTechName:
+		return "tech"
+	case MatchByUID:
+		return "uid"
+	default:
+		return fmt.Sprintf("MatchMode(%d)", int(m))
+	}
+}
+
+// tagPrefixes - префиксы тега esnsi, явно задающие способ сопоставления.
+var tagPrefixes = []struct {
+	prefix string
+	mode   MatchMode
+}{
+	{"name:", MatchByName},
+	{"tech:", MatchByTechName},
+	{"uid:", MatchByUID},
+}
+
+// Опции тега esnsi для полей, заполняемых данными записи, а не значениями атрибутов.
+const (
+	tagOptUID    = "uid"    // Идентификатор записи (атрибут uid элемента record)
+	tagOptAction = "action" // Действие над записью (атрибут action элемента record)
+)
+
+// tagOptOptional - опция тега esnsi для атрибута, который может отсутствовать в классификаторе.
+const tagOptOptional = "optional"
+
+// fieldTag - разобранный тег esnsi поля структуры записи.
+type fieldTag struct {
+	Key      string    // Наименование, техническое наименование или идентификатор атрибута
+	Mode     MatchMode // Способ сопоставления
+	Opt      string    // Опция тега: tagOptUID, tagOptAction или пустая строка
+	Optional bool      // Атрибут может отсутствовать в классификаторе (опция tagOptOptional)
+}
+
+// parseTag - разбирает значение тега esnsi.
+// Если тег не содержит префикса "name:", "tech:" или "uid:",
+// используется способ сопоставления по умолчанию def.
+// Теги вида ",uid" и ",action" задают поля для идентификатора записи и действия над записью.
+// Опция ",optional" после атрибута ("Код ОКАТО,optional") допускает отсутствие атрибута в классификаторе.
+func parseTag(tag string, def MatchMode) (fieldTag, error) {
+	// Наименование атрибута может содержать запятую,
+	// поэтому опцией считается только известное значение после последней запятой
+	if i := strings.LastIndex(tag, ","); i >= 0 {
+		key, opt := tag[:i], tag[i+1:]
+		switch opt {
+		case tagOptUID, tagOptAction:
+			if key != "" {
+				return fieldTag{}, fmt.Errorf("tag option '%s' does not accept attribute '%s'", opt, key)
+			}
+			return fieldTag{Opt: opt}, nil
+		case tagOptOptional:
+			ft, err := parseTag(key, def)
+			if err != nil {
+				return fieldTag{}, err
+			}
+			if ft.Key == "" || ft.Opt != "" {
+				return fieldTag{}, fmt.Errorf("tag option '%s' requires attribute", opt)
+			}
+			ft.Optional = true
+			return ft, nil
+		}
+	}
+	for _, p := range tagPrefixes {
+		if key, ok := strings.CutPrefix(tag, p.prefix); ok {
+			return fieldTag{Key: key, Mode: p.mode}, nil
+		}
+	}
+	return fieldTag{Key: tag, Mode: def}, nil
+}
+
+// Field - поле структуры записи, заполняемое значением атрибута классификатора.
+type Field struct {
+	Index    int       // Индекс поля в структуре записи
+	Name     string    // Имя поля структуры. Пример: "AdditionalData"
+	Attr     string    // Атрибут из тега esnsi без префикса. Пример: "Дополнительные данные"
+	Match    MatchMode // Способ сопоставления (MatchByName, если префикс тега не задан)
+	Optional bool      // Атрибут может отсутствовать в классификаторе (опция тега ",optional")
+}
+
+// Value - возвращает значение поля записи rec (указатель на структуру записи).
+func (f Field) Value(rec any) any {
+	return reflect.ValueOf(rec).Elem().Field(f.Index).Interface()
+}
+
+// Fields - возвращает поля структуры записи T, заполняемые значениями атрибутов,
+// в порядке объявления. Поля без тега esnsi и поля с тегами ",uid" и ",action" пропускаются.
+func Fields[T any]() ([]Field, error) {
+	return attrFields(reflect.TypeOf(*new(T)))
+}
+
+// attrFields - возвращает поля структуры записи typ, заполняемые значениями атрибутов.
+func attrFields(typ reflect.Type) ([]Field, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct type expected, got %s", typ.Kind())
+	}
+	var fields []Field
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		value := field.Tag.Get("esnsi")
+		if value == "" {
+			continue
+		}
+		tag, err := parseTag(value, MatchByName)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if tag.Opt != "" {
+			continue
+		}
+		fields = append(fields, Field{Index: i, Name: field.Name, Attr: tag.Key, Match: tag.Mode, Optional: tag.Optional})
+	}
+	return fields, nil
+}
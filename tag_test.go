@@ -0,0 +1,68 @@
+package esnsi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want fieldTag
+	}{
+		{"RegionName", fieldTag{Key: "RegionName", Mode: MatchByName}},
+		{"tech:region_name", fieldTag{Key: "region_name", Mode: MatchByTechName}},
+		{"uid:94737d3c", fieldTag{Key: "94737d3c", Mode: MatchByUID}},
+		{",uid", fieldTag{Opt: tagOptUID}},
+		{",action", fieldTag{Opt: tagOptAction}},
+		{"Код, ОКАТО", fieldTag{Key: "Код, ОКАТО", Mode: MatchByName}},
+		{"ОКАТО,optional", fieldTag{Key: "ОКАТО", Mode: MatchByName, Optional: true}},
+		{"tech:okato,optional", fieldTag{Key: "okato", Mode: MatchByTechName, Optional: true}},
+		{"Код, ОКАТО,optional", fieldTag{Key: "Код, ОКАТО", Mode: MatchByName, Optional: true}},
+	}
+	for _, tt := range tests {
+		got, err := parseTag(tt.tag, MatchByName)
+		if err != nil {
+			t.Errorf("parseTag(%q): unexpected error: %v", tt.tag, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTag(%q) = %+v, expected %+v", tt.tag, got, tt.want)
+		}
+	}
+
+	errs := []struct {
+		tag  string
+		want string
+	}{
+		{"Code,uid", "tag option 'uid' does not accept attribute 'Code'"},
+		{",optional", "tag option 'optional' requires attribute"},
+		{"tech:,optional", "tag option 'optional' requires attribute"},
+		{",uid,optional", "tag option 'optional' requires attribute"},
+	}
+	for _, tt := range errs {
+		if _, err := parseTag(tt.tag, MatchByName); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseTag(%q): unexpected error: %v", tt.tag, err)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields, err := Fields[testOptionalRecord]()
+	if err != nil {
+		t.Fatalf("failed to get fields: %v", err)
+	}
+	want := []Field{
+		{Index: 0, Name: "ToSfrCode", Attr: "ToSfrCode", Match: MatchByName},
+		{Index: 1, Name: "RegionName", Attr: "RegionName", Match: MatchByName, Optional: true},
+		{Index: 2, Name: "Missing", Attr: "missing", Match: MatchByTechName, Optional: true},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("unexpected fields:\n%+v\nexpected:\n%+v", fields, want)
+	}
+
+	if _, err = Fields[testWrongOptionalRecord](); err == nil {
+		t.Error("expected error, got nil")
+	}
+}